@@ -20,7 +20,9 @@ import (
 	"os"
 
 	"github.com/kyma-incubator/octopus/pkg/apis"
+	ctrlconfig "github.com/kyma-incubator/octopus/pkg/config"
 	"github.com/kyma-incubator/octopus/pkg/controller"
+	"github.com/kyma-incubator/octopus/pkg/logs"
 	"github.com/kyma-incubator/octopus/pkg/webhook"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -30,8 +32,19 @@ import (
 )
 
 func main() {
-	var metricsAddr string
+	var metricsAddr, webhookCertDir string
+	var enableWebhooks bool
+	var webhookPort int
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Enable defaulting and validating admission webhooks.")
+	flag.IntVar(&webhookPort, "webhook-port", 9876, "The port the webhook server binds to.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/cert", "The directory that contains the webhook server key and certificate.")
+	var ctrlCfg ctrlconfig.Config
+	flag.StringVar(&ctrlCfg.Logs.Sink, "logs-sink", logs.SinkConfigMap, "Where logs of finished testing pods are stored: none, configmap or filesystem.")
+	flag.StringVar(&ctrlCfg.Logs.Dir, "logs-dir", "", "The directory where the filesystem logs sink stores logs of testing pods.")
+	flag.Int64Var(&ctrlCfg.Logs.TailLines, "logs-tail-lines", 1000, "The number of lines collected from every container of a testing pod. All lines are collected if 0.")
+	flag.Int64Var(&ctrlCfg.Logs.LimitBytes, "logs-limit-bytes", 256*1024, "The number of bytes collected from every container of a testing pod. All bytes are collected if 0.")
+	flag.IntVar(&ctrlCfg.Flakiness.HistoryLimit, "flakiness-history-limit", 10, "The number of the most recent suites kept in the status of every TestDefinition. History is not recorded if 0.")
 	flag.Parse()
 	logf.SetLogger(logf.ZapLogger(false))
 	log := logf.Log.WithName("entrypoint")
@@ -46,7 +59,11 @@ func main() {
 
 	// Create a new Cmd to provide shared dependencies and start components
 	log.Info("setting up manager")
-	mgr, err := manager.New(cfg, manager.Options{MetricsBindAddress: metricsAddr})
+	mgr, err := manager.New(cfg, manager.Options{
+		MetricsBindAddress: metricsAddr,
+		Port:               webhookPort,
+		CertDir:            webhookCertDir,
+	})
 	if err != nil {
 		log.Error(err, "unable to set up overall controller manager")
 		os.Exit(1)
@@ -63,15 +80,17 @@ func main() {
 
 	// Setup all Controllers
 	log.Info("Setting up controller")
-	if err := controller.AddToManager(mgr); err != nil {
+	if err := controller.AddToManager(mgr, ctrlCfg); err != nil {
 		log.Error(err, "unable to register controllers to the manager")
 		os.Exit(1)
 	}
 
-	log.Info("setting up webhooks")
-	if err := webhook.AddToManager(mgr); err != nil {
-		log.Error(err, "unable to register webhooks to the manager")
-		os.Exit(1)
+	if enableWebhooks {
+		log.Info("setting up webhooks")
+		if err := webhook.AddToManager(mgr); err != nil {
+			log.Error(err, "unable to register webhooks to the manager")
+			os.Exit(1)
+		}
 	}
 
 	// Start the Cmd
@@ -0,0 +1,134 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/kyma-incubator/octopus/pkg/apis"
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/report"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const usage = `octopusctl is a command line client for octopus.
+
+Usage:
+  octopusctl junit [flags]    Print results of a ClusterTestSuite or TestSuite as a JUnit XML report
+
+Run "octopusctl <command> -h" to see flags of the command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "junit":
+		err = runJUnit(os.Args[2:])
+	case "-h", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func runJUnit(args []string) error {
+	fs := flag.NewFlagSet("junit", flag.ExitOnError)
+	name := fs.String("name", "", "Name of the suite fetched from the cluster.")
+	namespace := fs.String("namespace", "", "Namespace of the TestSuite. ClusterTestSuite is fetched if empty.")
+	file := fs.String("file", "", "Path to the YAML or JSON file with the suite, e.g. output of 'kubectl get cts <name> -o yaml'. Use - to read from the standard input.")
+	output := fs.String("output", "", "Path to the file where the report is written. The report is written to the standard output if empty.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if (*name == "") == (*file == "") {
+		return errors.New("exactly one of --name and --file must be provided")
+	}
+
+	var suite v1alpha1.GenericTestSuite
+	var err error
+	if *file != "" {
+		suite, err = readSuite(*file)
+	} else {
+		suite, err = fetchSuite(*name, *namespace)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return report.WriteJUnit(os.Stdout, suite)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return errors.Wrapf(err, "while creating report file [%s]", *output)
+	}
+	if err := report.WriteJUnit(f, suite); err != nil {
+		f.Close()
+		return err
+	}
+	return errors.Wrapf(f.Close(), "while closing report file [%s]", *output)
+}
+
+func readSuite(path string) (v1alpha1.GenericTestSuite, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading suite from [%s]", path)
+	}
+	return report.DecodeSuite(data)
+}
+
+func fetchSuite(name, namespace string) (v1alpha1.GenericTestSuite, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting client config")
+	}
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrap(err, "while adding APIs to scheme")
+	}
+	cli, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating client")
+	}
+	return report.FetchSuite(context.Background(), cli, name, namespace)
+}
@@ -1,4 +1,5 @@
 #!/usr/bin/env bash
 
 cd ./pkg/scheduler
-mockery -name=StatusProvider -output=automock -outpkg=automock -case=underscore
\ No newline at end of file
+mockery -name=StatusProvider -output=automock -outpkg=automock -case=underscore
+mockery -name=EventRecorder -output=automock -outpkg=automock -case=underscore
\ No newline at end of file
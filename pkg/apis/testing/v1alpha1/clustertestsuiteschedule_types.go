@@ -0,0 +1,90 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConcurrencyPolicy describes how a suite created by a schedule is handled when the previous one is still running
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows suites created by a schedule to run concurrently
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent postpones creating a new suite until the previous one finishes
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent deletes running suites before creating a new one
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+
+// ClusterTestSuiteSchedule creates ClusterTestSuites periodically, similarly to a CronJob
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clustertestsuiteschedules,shortName=ctss
+type ClusterTestSuiteSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterTestSuiteScheduleSpec   `json:"spec,omitempty"`
+	Status ClusterTestSuiteScheduleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+
+// ClusterTestSuiteScheduleList contains a list of ClusterTestSuiteSchedule
+type ClusterTestSuiteScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterTestSuiteSchedule `json:"items"`
+}
+
+// ClusterTestSuiteScheduleSpec defines the desired state of ClusterTestSuiteSchedule
+type ClusterTestSuiteScheduleSpec struct {
+	// Schedule in the Cron format, e.g. "0 2 * * *". Times are in the timezone of the controller.
+	Schedule string `json:"schedule"`
+	// Spec of ClusterTestSuites created by the schedule
+	Template TestSuiteSpec `json:"template"`
+	// How to treat a new suite if the previous one is still running: Allow, Forbid or Replace.
+	// Default value is Forbid
+	// +kubebuilder:validation:Enum=Allow,Forbid,Replace
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// Suspend stops creating new suites. Suites that are already running are not affected.
+	Suspend bool `json:"suspend,omitempty"`
+	// How many succeeded suites are kept. Default value is 3
+	// +kubebuilder:validation:Minimum=0
+	SuccessfulSuitesHistoryLimit *int32 `json:"successfulSuitesHistoryLimit,omitempty"`
+	// How many failed suites are kept. Default value is 1
+	// +kubebuilder:validation:Minimum=0
+	FailedSuitesHistoryLimit *int32 `json:"failedSuitesHistoryLimit,omitempty"`
+}
+
+// ClusterTestSuiteScheduleStatus defines the observed state of ClusterTestSuiteSchedule
+type ClusterTestSuiteScheduleStatus struct {
+	// Names of ClusterTestSuites created by the schedule that are not finished yet
+	Active []string `json:"active,omitempty"`
+	// When a suite was created by the schedule for the last time
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterTestSuiteSchedule{}, &ClusterTestSuiteScheduleList{})
+}
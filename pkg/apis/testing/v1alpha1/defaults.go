@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultConcurrency        int64 = 1
+	DefaultCount              int64 = 1
+	DefaultSuiteTimeout             = time.Hour
+	DefaultPendingTimeout           = 5 * time.Minute
+	DefaultPodRetentionPolicy       = PodRetentionKeepAll
+
+	DefaultConcurrencyPolicy                  = ForbidConcurrent
+	DefaultSuccessfulSuitesHistoryLimit int32 = 3
+	DefaultFailedSuitesHistoryLimit     int32 = 1
+)
+
+// SetDefaults sets default values for all fields that were not provided by the user.
+func (in *TestSuiteSpec) SetDefaults() {
+	if in.Concurrency == 0 && len(in.ResourceBudget) == 0 {
+		in.Concurrency = DefaultConcurrency
+	}
+	if in.Count == 0 {
+		in.Count = DefaultCount
+	}
+	if in.SuiteTimeout == nil {
+		in.SuiteTimeout = &metav1.Duration{Duration: DefaultSuiteTimeout}
+	}
+	if in.PendingTimeout == nil {
+		in.PendingTimeout = &metav1.Duration{Duration: DefaultPendingTimeout}
+	}
+	if in.PodRetentionPolicy == "" {
+		in.PodRetentionPolicy = DefaultPodRetentionPolicy
+	}
+}
+
+// SetDefaults sets default values for all fields that were not provided by the user.
+// The suite template is defaulted when a suite is created from it.
+func (in *ClusterTestSuiteScheduleSpec) SetDefaults() {
+	if in.ConcurrencyPolicy == "" {
+		in.ConcurrencyPolicy = DefaultConcurrencyPolicy
+	}
+	if in.SuccessfulSuitesHistoryLimit == nil {
+		limit := DefaultSuccessfulSuitesHistoryLimit
+		in.SuccessfulSuitesHistoryLimit = &limit
+	}
+	if in.FailedSuitesHistoryLimit == nil {
+		limit := DefaultFailedSuitesHistoryLimit
+		in.FailedSuitesHistoryLimit = &limit
+	}
+}
@@ -0,0 +1,29 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// GenericTestSuite is implemented by ClusterTestSuite and TestSuite, so both kinds can be handled by the same logic.
+// Namespace of a ClusterTestSuite is always empty.
+// +k8s:deepcopy-gen=false
+type GenericTestSuite interface {
+	runtime.Object
+	metav1.Object
+
+	GetSpec() *TestSuiteSpec
+	GetStatus() *TestSuiteStatus
+	SetStatus(status TestSuiteStatus)
+	HasSelector() bool
+	// Copy returns a deep copy of the suite
+	Copy() GenericTestSuite
+}
+
+var _ GenericTestSuite = &ClusterTestSuite{}
+var _ GenericTestSuite = &TestSuite{}
+
+// IsNamespaced returns true if the suite is a namespaced TestSuite
+func IsNamespaced(suite GenericTestSuite) bool {
+	return suite.GetNamespace() != ""
+}
@@ -4,4 +4,17 @@ const (
 	LabelKeyCreatedByOctopus = "testing.kyma-project.io/created-by-octopus"
 	LabelKeySuiteName        = "testing.kyma-project.io/suite-name"
 	LabelKeyTestDefName      = "testing.kyma-project.io/def-name"
+	LabelKeyVariant          = "testing.kyma-project.io/variant"
+	LabelKeyScheduleName     = "testing.kyma-project.io/schedule-name"
+)
+
+const (
+	// AnnotationKeyRerun set on a finished suite reopens it. The only supported value is RerunFailed.
+	// The annotation is removed by the controller once the request is handled.
+	AnnotationKeyRerun = "testing.kyma-project.io/rerun"
+	// RerunFailed reruns tests that failed or were skipped because of other failed tests
+	RerunFailed = "failed"
+	// AnnotationKeyAbort set on a running suite aborts it, regardless of the value. The annotation is removed
+	// by the controller once the request is handled.
+	AnnotationKeyAbort = "testing.kyma-project.io/abort"
 )
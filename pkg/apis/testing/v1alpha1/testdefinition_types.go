@@ -27,12 +27,14 @@ import (
 
 // TestDefinition is the Schema for the testdefinitions API
 // +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:path=testdefinitions,shortName=td
 type TestDefinition struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec TestDefinitionSpec `json:"spec,omitempty"`
+	Spec   TestDefinitionSpec   `json:"spec,omitempty"`
+	Status TestDefinitionStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -55,10 +57,51 @@ type TestDefinitionSpec struct {
 	// I would like to run it in separation.
 	// Default value is false
 	DisableConcurrency bool `json:"disableConcurrency,omitempty"`
+	// Tests with the same concurrency group are never executed at the same time, even if they are in different namespaces.
+	// Tests from different groups and tests without a group can still run in parallel.
+	// No default value.
+	ConcurrencyGroup string `json:"concurrencyGroup,omitempty"`
 	// Test should be interrupted after the timeout.
 	// On test suite level such test should be marked as a timeouted.
 	// No default value.
 	Timeout *metav1.Duration `json:"timeout,inline,omitempty"`
+	// Tests that have to succeed before this test is executed. They have to be selected by the same suite.
+	// If any of them does not succeed, this test is skipped.
+	DependsOn []TestDefReference `json:"dependsOn,omitempty"`
+	// Variants of the test, e.g. to run the same test against different backends. Every variant is executed
+	// as a separate test with its parameters injected as environment variables into all containers of the testing pod.
+	// If empty, the test is executed once without parameters.
+	Matrix []TestVariant `json:"matrix,omitempty"`
+}
+
+// TestVariant is a named set of parameters of a test
+type TestVariant struct {
+	// Name of the variant, used in names and labels of testing pods
+	Name string `json:"name"`
+	// Parameters injected as environment variables
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// TestDefinitionStatus defines the observed state of TestDefinition
+type TestDefinitionStatus struct {
+	// Results of the test in the most recent finished suites, the newest first
+	History []TestRunSummary `json:"history,omitempty"`
+	// How many suites from History detected the test as flaky
+	FlakyRuns int64 `json:"flakyRuns,omitempty"`
+	// How many suites from History detected the test as failed
+	FailedRuns int64 `json:"failedRuns,omitempty"`
+}
+
+// TestRunSummary describes the result of the test in a single suite
+type TestRunSummary struct {
+	// Name of the suite, prefixed with the namespace in case of a TestSuite
+	Suite string `json:"suite"`
+	// Variant of the test from the TestDefinition matrix
+	Variant          string       `json:"variant,omitempty"`
+	Status           TestStatus   `json:"status"`
+	Executions       int64        `json:"executions"`
+	FailedExecutions int64        `json:"failedExecutions,omitempty"`
+	CompletionTime   *metav1.Time `json:"completionTime,omitempty"`
 }
 
 func init() {
@@ -40,6 +40,8 @@ const (
 	SuiteFailed TestSuiteConditionType = "Failed"
 	// When all tests passed
 	SuiteSucceeded TestSuiteConditionType = "Succeeded"
+	// When suite was aborted on user request before all tests were finished
+	SuiteAborted TestSuiteConditionType = "Aborted"
 
 	// TestStatus represents status of a given test (test-kubeless) , not a test execution, because we can have many
 	// executions of the same tests (in case of MaxRetries>0 or Count>1)
@@ -53,8 +55,60 @@ const (
 	TestFailed    TestStatus = "Failed"
 	TestSucceeded TestStatus = "Succeeded"
 	TestSkipped   TestStatus = "Skipped"
+	// TestFlaky is set on a test that both failed and succeeded in different executions
+	TestFlaky TestStatus = "Flaky"
 
 	ReasonErrorOnInitialization = "initializationFailure"
+	// ReasonSuiteTimeout is set on the Error condition of a suite that was interrupted because it exceeded SuiteTimeout
+	ReasonSuiteTimeout = "suiteTimeout"
+	// ReasonPodsStuckPending is set on the Error condition of a suite with tests that failed because their testing pods
+	// were stuck in the Pending phase, e.g. because of a missing test image
+	ReasonPodsStuckPending = "podsStuckPending"
+
+	// ExecutionReasonTimedOut is set on a test execution that was interrupted because it took longer than
+	// the timeout defined in the TestDefinition
+	ExecutionReasonTimedOut = "TimedOut"
+	// ExecutionReasonSuiteTimedOut is set on a test execution that was interrupted because the whole suite
+	// exceeded SuiteTimeout
+	ExecutionReasonSuiteTimedOut = "SuiteTimedOut"
+	// TestReasonSuiteTimedOut is set on a test that was skipped because the suite exceeded SuiteTimeout
+	TestReasonSuiteTimedOut = "SuiteTimedOut"
+	// ExecutionReasonStuckPending is set on a test execution that failed because its testing pod was stuck
+	// in the Pending phase for longer than PendingTimeout
+	ExecutionReasonStuckPending = "StuckPending"
+	// ExecutionReasonPodDeleted is set on a test execution that failed because its testing pod was deleted
+	// by someone else than Octopus before the execution finished, e.g. because a node was drained
+	ExecutionReasonPodDeleted = "PodDeleted"
+	// ExecutionReasonNodeLost is set on a test execution that failed because the node of its testing pod was lost
+	ExecutionReasonNodeLost = "NodeLost"
+
+	// TestReasonDependencyNotSucceeded is set on a test that was skipped because one of the tests it depends on failed or was skipped
+	TestReasonDependencyNotSucceeded = "DependencyNotSucceeded"
+
+	// ReasonFailureThresholdReached is set on the Running and Failed conditions of a suite that was stopped
+	// because too many tests failed
+	ReasonFailureThresholdReached = "failureThresholdReached"
+	// ReasonFlakyTests is set on the Succeeded and Failed conditions of a suite with flaky tests and no other failed tests
+	ReasonFlakyTests = "flakyTests"
+	// ExecutionReasonAborted is set on a test execution that was interrupted because the suite was stopped
+	// after too many tests failed
+	ExecutionReasonAborted = "Aborted"
+	// TestReasonSuiteStopped is set on a test that was skipped because the suite was stopped after too many tests failed
+	TestReasonSuiteStopped = "SuiteStopped"
+
+	// ReasonSetupFailed is set on the Failed condition of a suite whose setup failed
+	ReasonSetupFailed = "setupFailed"
+	// TestReasonSetupFailed is set on a test that was skipped because the setup of the suite failed
+	TestReasonSetupFailed = "SetupFailed"
+
+	// ExecutionReasonSuiteAborted is set on a test execution that was interrupted because the suite was aborted
+	ExecutionReasonSuiteAborted = "SuiteAborted"
+	// TestReasonSuiteAborted is set on a test that was skipped because the suite was aborted
+	TestReasonSuiteAborted = "SuiteAborted"
+
+	// TestReasonNoTestsExecuted is set on the setup or teardown that was skipped because the suite finished
+	// without executing any test, e.g. because all tests were skipped
+	TestReasonNoTestsExecuted = "NoTestsExecuted"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -85,17 +139,64 @@ type ClusterTestSuiteList struct {
 	Items           []ClusterTestSuite `json:"items"`
 }
 
-// TestSuiteSpec defines the desired state of ClusterTestSuite
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TestSuite is a namespaced equivalent of ClusterTestSuite. It can select only TestDefinitions from its own namespace.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=testsuites,shortName=ts
+type TestSuite struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TestSuiteSpec   `json:"spec,omitempty"`
+	Status TestSuiteStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TestSuiteList contains a list of TestSuite
+type TestSuiteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TestSuite `json:"items"`
+}
+
+// PodRetentionPolicy describes which testing pods are kept once the suite is finished
+type PodRetentionPolicy string
+
+const (
+	// PodRetentionKeepAll keeps all testing pods until the suite is deleted
+	PodRetentionKeepAll PodRetentionPolicy = "KeepAll"
+	// PodRetentionKeepFailed deletes testing pods of succeeded executions
+	PodRetentionKeepFailed PodRetentionPolicy = "KeepFailed"
+	// PodRetentionKeepNone deletes all testing pods
+	PodRetentionKeepNone PodRetentionPolicy = "KeepNone"
+)
+
+// TestSuiteSpec defines the desired state of ClusterTestSuite and TestSuite
 type TestSuiteSpec struct {
 	// How many tests we want to execute at the same time.
 	// Depends on cluster size and it's load.
-	// Default value is 1
+	// Default value is 1, or no limit if ResourceBudget is defined
 	Concurrency int64 `json:"concurrency,omitempty"`
+	// Total CPU and memory requests of testing pods that can run at the same time.
+	// Requests of a testing pod are calculated from containers of the TestDefinition pod template.
+	// A test that does not fit into the remaining budget waits until other tests finish,
+	// unless no other test is running.
+	// Only cpu and memory resources are supported. There is no default value.
+	ResourceBudget v1.ResourceList `json:"resourceBudget,omitempty"`
 	// Decide which tests to execute. If not provided execute all tests
 	Selectors TestsSelector `json:"selectors,omitempty"`
 	// Running all tests from suite cannot take more time that specified here.
 	// Default value is 1h
 	SuiteTimeout *metav1.Duration `json:"suiteTimeout,inline,omitempty"`
+	// How long a testing pod can be stuck in the Pending phase because its image cannot be pulled,
+	// its container configuration is invalid or it cannot be scheduled. Such execution is failed afterwards
+	// and its testing pod is deleted.
+	// Default value is 5m
+	PendingTimeout *metav1.Duration `json:"pendingTimeout,omitempty"`
 	// How many times should I run every test? Default value is 1.
 	Count int64 `json:"count,omitempty"`
 	// In case of a failed test, how many times it will be retried.
@@ -103,6 +204,87 @@ type TestSuiteSpec struct {
 	// Default value is 0 - no retries.
 	// MaxRetries and Count cannot be used mutually.
 	MaxRetries int64 `json:"maxRetries,omitempty"`
+	// Stop the suite after the first failed test. It is a shortcut for MaxFailures equal to 1.
+	// Default value is false
+	FailFast bool `json:"failFast,omitempty"`
+	// Stop the suite once that many tests failed. No new testing pods are created and tests that were not executed
+	// are marked as skipped. Takes precedence over FailFast.
+	// Default value is 0 - no limit.
+	MaxFailures int64 `json:"maxFailures,omitempty"`
+	// When the suite is stopped because of FailFast or MaxFailures, interrupt tests that are in progress
+	// instead of waiting for them to finish.
+	// Default value is false
+	AbortRunningOnFailure bool `json:"abortRunningOnFailure,omitempty"`
+	// TestDefinition executed before any test of the suite, e.g. to seed data. If it fails, tests are skipped
+	// and the suite fails. It is not executed as a regular test even if it matches the selectors.
+	// No default value.
+	Setup *TestDefReference `json:"setup,omitempty"`
+	// TestDefinition executed after all tests are finished, regardless of their results and also when the suite
+	// exceeded SuiteTimeout. It is not executed as a regular test even if it matches the selectors.
+	// No default value.
+	Teardown *TestDefReference `json:"teardown,omitempty"`
+	// Environment variables added to all containers of testing pods, e.g. to pass the target cluster URL or the build ID.
+	// They override variables with the same name from TestDefinitions, but not parameters from the TestDefinition matrix.
+	Env []v1.EnvVar `json:"env,omitempty"`
+	// Sources of environment variables, e.g. ConfigMaps or Secrets, added to all containers of testing pods after
+	// sources from TestDefinitions. Referenced objects have to exist in namespaces of the TestDefinitions.
+	EnvFrom []v1.EnvFromSource `json:"envFrom,omitempty"`
+	// Volumes added to testing pods. A volume replaces a volume with the same name from the TestDefinition.
+	Volumes []v1.Volume `json:"volumes,omitempty"`
+	// Mounts of Volumes added to all containers of testing pods. A mount replaces a mount with the same path
+	// from the TestDefinition.
+	VolumeMounts []v1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Stop creating new testing pods, e.g. for a cluster maintenance window. Executions in progress are finished
+	// and recorded. Once the suite is resumed, it continues where it stopped. Unlike other fields, it can be changed
+	// after the suite is created.
+	// Default value is false
+	Paused bool `json:"paused,omitempty"`
+	// Do not count the time when the suite is paused into SuiteTimeout.
+	// Default value is false
+	PauseSuiteTimeout bool `json:"pauseSuiteTimeout,omitempty"`
+	// Which testing pods are kept once the suite is finished: KeepAll, KeepFailed or KeepNone. Other testing pods
+	// are deleted. Default value is KeepAll
+	// +kubebuilder:validation:Enum=KeepAll,KeepFailed,KeepNone
+	PodRetentionPolicy PodRetentionPolicy `json:"podRetentionPolicy,omitempty"`
+	// Testing pods kept by the PodRetentionPolicy are deleted after this number of seconds since the suite completion.
+	// No default value, which means that they are kept until the suite is deleted.
+	PodTTLSecondsAfterFinished *int64 `json:"podTTLSecondsAfterFinished,omitempty"`
+	// The suite, together with its testing pods, is deleted after this number of seconds since its completion.
+	// No default value, which means that the suite is not deleted automatically.
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// IsHook returns true if the TestDefinition is the setup or the teardown of the suite
+func (in *TestSuiteSpec) IsHook(def TestDefinition) bool {
+	for _, ref := range []*TestDefReference{in.Setup, in.Teardown} {
+		if ref != nil && ref.Name == def.Name && ref.Namespace == def.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTestSucceeded returns true if the test status counts as a success for the suite.
+// A flaky test succeeds if it was retried until it passed.
+func (in *TestSuiteSpec) IsTestSucceeded(st TestStatus) bool {
+	return st == TestSucceeded || (st == TestFlaky && in.MaxRetries > 0)
+}
+
+// IsTestFailed returns true if the test status counts as a failure for the suite.
+// A flaky test fails if it is executed Count times without retries.
+func (in *TestSuiteSpec) IsTestFailed(st TestStatus) bool {
+	return st == TestFailed || (st == TestFlaky && in.MaxRetries == 0)
+}
+
+// GetFailureThreshold returns how many tests can fail before the suite is stopped, or 0 if there is no limit
+func (in *TestSuiteSpec) GetFailureThreshold() int64 {
+	if in.MaxFailures > 0 {
+		return in.MaxFailures
+	}
+	if in.FailFast {
+		return 1
+	}
+	return 0
 }
 
 type TestsSelector struct {
@@ -119,12 +301,39 @@ type TestDefReference struct {
 	Namespace string `json:"namespace"`
 }
 
-// TestSuiteStatus defines the observed state of ClusterTestSuite
+// TestSuiteStatus defines the observed state of ClusterTestSuite and TestSuite
 type TestSuiteStatus struct {
 	StartTime      *metav1.Time         `json:"startTime,inline,omitempty"`
 	CompletionTime *metav1.Time         `json:"completionTime,inline,omitempty"`
 	Conditions     []TestSuiteCondition `json:"conditions,omitempty"`
 	Results        []TestResult         `json:"results,omitempty"`
+	// Results of the setup and teardown TestDefinitions
+	Setup    *TestResult `json:"setup,omitempty"`
+	Teardown *TestResult `json:"teardown,omitempty"`
+	// When the suite was paused, empty if the suite is not paused
+	PausedAt *metav1.Time `json:"pausedAt,omitempty"`
+	// How long the suite was paused in total, not including the current pause
+	PausedDuration *metav1.Duration `json:"pausedDuration,omitempty"`
+}
+
+// GetAllResults returns test results followed by results of the setup and teardown, if they are defined.
+// Results are returned as pointers, so executions of testing pods can be updated in place.
+func (in *TestSuiteStatus) GetAllResults() []*TestResult {
+	out := make([]*TestResult, 0, len(in.Results)+2)
+	for idx := range in.Results {
+		out = append(out, &in.Results[idx])
+	}
+	for _, hook := range []*TestResult{in.Setup, in.Teardown} {
+		if hook != nil {
+			out = append(out, hook)
+		}
+	}
+	return out
+}
+
+// IsVariantOf returns true if the result belongs to the given variant of the TestDefinition
+func (in TestResult) IsVariantOf(name, namespace, variant string) bool {
+	return in.Name == name && in.Namespace == namespace && in.Variant == variant
 }
 
 type TestSuiteCondition struct {
@@ -137,11 +346,25 @@ type TestSuiteCondition struct {
 // TestResult gathers all executions for given TestDefinition
 type TestResult struct {
 	// Test name
-	Name                string          `json:"name"`
-	Namespace           string          `json:"namespace"`
-	Status              TestStatus      `json:"status"`
-	Executions          []TestExecution `json:"executions"`
-	DisabledConcurrency bool            `json:"disabledConcurrency,omitempty"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Variant and Parameters copied from the matrix of the TestDefinition. Every variant has a separate result.
+	Variant             string            `json:"variant,omitempty"`
+	Parameters          map[string]string `json:"parameters,omitempty"`
+	Status              TestStatus        `json:"status"`
+	Executions          []TestExecution   `json:"executions"`
+	DisabledConcurrency bool              `json:"disabledConcurrency,omitempty"`
+	// ConcurrencyGroup copied from the TestDefinition. The test is not scheduled while a test from the same group is in progress.
+	ConcurrencyGroup string `json:"concurrencyGroup,omitempty"`
+	// Timeout copied from the TestDefinition. Execution that takes longer is interrupted.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// DependsOn copied from the TestDefinition. The test is scheduled only after all these tests succeeded.
+	DependsOn []TestDefReference `json:"dependsOn,omitempty"`
+	// Reason and Message explain the status of the test, e.g. why it was skipped
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Executions from previous runs of the suite, kept when the test is rerun
+	PreviousExecutions []TestExecution `json:"previousExecutions,omitempty"`
 }
 
 // TestExecution provides status for given test execution
@@ -153,12 +376,72 @@ type TestExecution struct {
 	CompletionTime *metav1.Time `json:"completionTime,inline,omitempty"`
 	Reason         string       `json:"reason,omitempty"`
 	Message        string       `json:"message,omitempty"`
+	// LogsRef points to logs collected from containers of the testing Pod after the execution finished,
+	// e.g. configmap://default/oct-tp-suite-test-0
+	LogsRef string `json:"logsRef,omitempty"`
+	// Containers of the testing Pod, including init containers, recorded when the execution finished
+	Containers []ContainerResult `json:"containers,omitempty"`
+}
+
+// ContainerResult describes how a container of a testing Pod terminated
+type ContainerResult struct {
+	Name string `json:"name"`
+	// InitContainer is true for init containers of the testing Pod
+	InitContainer bool `json:"initContainer,omitempty"`
+	// ExitCode of the terminated container, empty if the container did not terminate
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	// Reason of the termination, e.g. Completed, Error, OOMKilled or DeadlineExceeded
+	Reason string `json:"reason,omitempty"`
+	// Message written by the container to its termination message path, e.g. /dev/termination-log
+	Message      string `json:"message,omitempty"`
+	RestartCount int32  `json:"restartCount,omitempty"`
 }
 
 func init() {
 	SchemeBuilder.Register(&ClusterTestSuite{}, &ClusterTestSuiteList{})
+	SchemeBuilder.Register(&TestSuite{}, &TestSuiteList{})
+}
+
+func (in TestSuiteSpec) HasSelector() bool {
+	return len(in.Selectors.MatchNames) > 0 || len(in.Selectors.MatchLabelExpressions) > 0
 }
 
 func (in ClusterTestSuite) HasSelector() bool {
-	return len(in.Spec.Selectors.MatchNames) > 0 || len(in.Spec.Selectors.MatchLabelExpressions) > 0
+	return in.Spec.HasSelector()
+}
+
+func (in *ClusterTestSuite) GetSpec() *TestSuiteSpec {
+	return &in.Spec
+}
+
+func (in *ClusterTestSuite) GetStatus() *TestSuiteStatus {
+	return &in.Status
+}
+
+func (in *ClusterTestSuite) SetStatus(status TestSuiteStatus) {
+	in.Status = status
+}
+
+func (in *ClusterTestSuite) Copy() GenericTestSuite {
+	return in.DeepCopy()
+}
+
+func (in TestSuite) HasSelector() bool {
+	return in.Spec.HasSelector()
+}
+
+func (in *TestSuite) GetSpec() *TestSuiteSpec {
+	return &in.Spec
+}
+
+func (in *TestSuite) GetStatus() *TestSuiteStatus {
+	return &in.Status
+}
+
+func (in *TestSuite) SetStatus(status TestSuiteStatus) {
+	in.Status = status
+}
+
+func (in *TestSuite) Copy() GenericTestSuite {
+	return in.DeepCopy()
 }
@@ -19,6 +19,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -84,6 +85,140 @@ func (in *ClusterTestSuiteList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTestSuiteSchedule) DeepCopyInto(out *ClusterTestSuiteSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTestSuiteSchedule.
+func (in *ClusterTestSuiteSchedule) DeepCopy() *ClusterTestSuiteSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTestSuiteSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTestSuiteSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTestSuiteScheduleList) DeepCopyInto(out *ClusterTestSuiteScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTestSuiteSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTestSuiteScheduleList.
+func (in *ClusterTestSuiteScheduleList) DeepCopy() *ClusterTestSuiteScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTestSuiteScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTestSuiteScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTestSuiteScheduleSpec) DeepCopyInto(out *ClusterTestSuiteScheduleSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.SuccessfulSuitesHistoryLimit != nil {
+		in, out := &in.SuccessfulSuitesHistoryLimit, &out.SuccessfulSuitesHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedSuitesHistoryLimit != nil {
+		in, out := &in.FailedSuitesHistoryLimit, &out.FailedSuitesHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTestSuiteScheduleSpec.
+func (in *ClusterTestSuiteScheduleSpec) DeepCopy() *ClusterTestSuiteScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTestSuiteScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTestSuiteScheduleStatus) DeepCopyInto(out *ClusterTestSuiteScheduleStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTestSuiteScheduleStatus.
+func (in *ClusterTestSuiteScheduleStatus) DeepCopy() *ClusterTestSuiteScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTestSuiteScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerResult) DeepCopyInto(out *ContainerResult) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerResult.
+func (in *ContainerResult) DeepCopy() *ContainerResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestDefReference) DeepCopyInto(out *TestDefReference) {
 	*out = *in
@@ -106,6 +241,7 @@ func (in *TestDefinition) DeepCopyInto(out *TestDefinition) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -169,6 +305,18 @@ func (in *TestDefinitionSpec) DeepCopyInto(out *TestDefinitionSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]TestDefReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make([]TestVariant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -182,6 +330,29 @@ func (in *TestDefinitionSpec) DeepCopy() *TestDefinitionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestDefinitionStatus) DeepCopyInto(out *TestDefinitionStatus) {
+	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]TestRunSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestDefinitionStatus.
+func (in *TestDefinitionStatus) DeepCopy() *TestDefinitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TestDefinitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestExecution) DeepCopyInto(out *TestExecution) {
 	*out = *in
@@ -193,6 +364,13 @@ func (in *TestExecution) DeepCopyInto(out *TestExecution) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -209,6 +387,13 @@ func (in *TestExecution) DeepCopy() *TestExecution {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestResult) DeepCopyInto(out *TestResult) {
 	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Executions != nil {
 		in, out := &in.Executions, &out.Executions
 		*out = make([]TestExecution, len(*in))
@@ -216,6 +401,23 @@ func (in *TestResult) DeepCopyInto(out *TestResult) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]TestDefReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreviousExecutions != nil {
+		in, out := &in.PreviousExecutions, &out.PreviousExecutions
+		*out = make([]TestExecution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -229,6 +431,54 @@ func (in *TestResult) DeepCopy() *TestResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestRunSummary) DeepCopyInto(out *TestRunSummary) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestRunSummary.
+func (in *TestRunSummary) DeepCopy() *TestRunSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TestRunSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestSuite) DeepCopyInto(out *TestSuite) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestSuite.
+func (in *TestSuite) DeepCopy() *TestSuite {
+	if in == nil {
+		return nil
+	}
+	out := new(TestSuite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TestSuite) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestSuiteCondition) DeepCopyInto(out *TestSuiteCondition) {
 	*out = *in
@@ -245,15 +495,108 @@ func (in *TestSuiteCondition) DeepCopy() *TestSuiteCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestSuiteList) DeepCopyInto(out *TestSuiteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TestSuite, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestSuiteList.
+func (in *TestSuiteList) DeepCopy() *TestSuiteList {
+	if in == nil {
+		return nil
+	}
+	out := new(TestSuiteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TestSuiteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestSuiteSpec) DeepCopyInto(out *TestSuiteSpec) {
 	*out = *in
+	if in.ResourceBudget != nil {
+		in, out := &in.ResourceBudget, &out.ResourceBudget
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	in.Selectors.DeepCopyInto(&out.Selectors)
 	if in.SuiteTimeout != nil {
 		in, out := &in.SuiteTimeout, &out.SuiteTimeout
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.PendingTimeout != nil {
+		in, out := &in.PendingTimeout, &out.PendingTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Setup != nil {
+		in, out := &in.Setup, &out.Setup
+		*out = new(TestDefReference)
+		**out = **in
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(TestDefReference)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodTTLSecondsAfterFinished != nil {
+		in, out := &in.PodTTLSecondsAfterFinished, &out.PodTTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
@@ -290,6 +633,25 @@ func (in *TestSuiteStatus) DeepCopyInto(out *TestSuiteStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Setup != nil {
+		in, out := &in.Setup, &out.Setup
+		*out = new(TestResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(TestResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PausedAt != nil {
+		in, out := &in.PausedAt, &out.PausedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PausedDuration != nil {
+		in, out := &in.PausedDuration, &out.PausedDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -303,6 +665,29 @@ func (in *TestSuiteStatus) DeepCopy() *TestSuiteStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestVariant) DeepCopyInto(out *TestVariant) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestVariant.
+func (in *TestVariant) DeepCopy() *TestVariant {
+	if in == nil {
+		return nil
+	}
+	out := new(TestVariant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestsSelector) DeepCopyInto(out *TestsSelector) {
 	*out = *in
@@ -0,0 +1,26 @@
+package config
+
+// Config holds settings of the controller manager provided as command line flags
+type Config struct {
+	Logs      LogsConfig
+	Flakiness FlakinessConfig
+}
+
+// LogsConfig defines how logs of testing pods are collected
+type LogsConfig struct {
+	// Sink defines where logs of finished testing pods are stored. Logs are not collected if Sink is empty or "none".
+	Sink string
+	// Dir is a directory where the filesystem sink stores logs, e.g. a mount path of a PersistentVolume
+	Dir string
+	// TailLines limits the number of lines collected from every container. All lines are collected if it is 0.
+	TailLines int64
+	// LimitBytes limits the number of bytes collected from every container. All bytes are collected if it is 0.
+	LimitBytes int64
+}
+
+// FlakinessConfig defines how results of tests are tracked across suites
+type FlakinessConfig struct {
+	// HistoryLimit is the number of the most recent suites kept in the status of every TestDefinition.
+	// History is not recorded if it is 0.
+	HistoryLimit int
+}
@@ -17,6 +17,7 @@ package testsuite
 
 import (
 	"context"
+	"fmt"
 	"github.com/kyma-incubator/octopus/pkg/humanerr"
 	"go.uber.org/multierr"
 	"time"
@@ -26,13 +27,22 @@ import (
 
 	"github.com/go-logr/logr"
 	testingv1alpha1 "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/config"
+	"github.com/kyma-incubator/octopus/pkg/events"
 	"github.com/kyma-incubator/octopus/pkg/fetcher"
+	"github.com/kyma-incubator/octopus/pkg/flakiness"
+	"github.com/kyma-incubator/octopus/pkg/logs"
+	"github.com/kyma-incubator/octopus/pkg/metrics"
+	"github.com/kyma-incubator/octopus/pkg/retention"
 	"github.com/kyma-incubator/octopus/pkg/scheduler"
 	"github.com/kyma-incubator/octopus/pkg/status"
+	"github.com/kyma-incubator/octopus/pkg/terminator"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -44,14 +54,29 @@ import (
 
 // Add creates a new ClusterTestSuite Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
-func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+func Add(mgr manager.Manager, cfg config.Config) error {
+	r, err := newReconciler(mgr, cfg)
+	if err != nil {
+		return err
+	}
+	return add(mgr, r)
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	statusSvc := status.NewService(time.Now)
-	schedulerSvc := scheduler.NewService(statusSvc, mgr.GetClient(), mgr.GetClient(), mgr.GetScheme(), logf.Log.WithName("scheduler"))
+func newReconciler(mgr manager.Manager, cfg config.Config) (reconcile.Reconciler, error) {
+	sink, err := logs.NewSink(cfg.Logs, mgr.GetClient(), mgr.GetScheme())
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating logs sink")
+	}
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating kubernetes clientset")
+	}
+	logCollector := logs.NewCollector(logs.NewClientsetLogsGetter(clientset), sink, cfg.Logs.TailLines, cfg.Logs.LimitBytes, logf.Log.WithName("logs"))
+
+	recorder := events.NewRecorder(mgr.GetEventRecorderFor("octopus"), mgr.GetClient(), logf.Log.WithName("events"))
+	statusSvc := status.NewService(time.Now, recorder)
+	schedulerSvc := scheduler.NewService(statusSvc, mgr.GetClient(), mgr.GetClient(), mgr.GetScheme(), recorder, logf.Log.WithName("scheduler"))
 	podSvc := fetcher.NewForTestingPod(mgr.GetClient())
 
 	return &ReconcileTestSuite{
@@ -61,8 +86,14 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 		statusService:     statusSvc,
 		definitionService: fetcher.NewForDefinition(mgr.GetClient()),
 		podSvc:            podSvc,
+		terminator:        terminator.NewService(mgr.GetClient(), logf.Log.WithName("terminator")),
+		logCollector:      logCollector,
+		metrics:           metrics.DefaultRecorder,
+		flakiness:         flakiness.NewService(mgr.GetClient(), cfg.Flakiness.HistoryLimit, logf.Log.WithName("flakiness")),
+		retention:         retention.NewService(mgr.GetClient(), time.Now, logf.Log.WithName("retention")),
+		recorder:          recorder,
 		log:               logf.Log.WithName("cts_controller"),
-		prevReconcile:     make(chan time.Time, 1)}
+		prevReconcile:     make(chan time.Time, 1)}, nil
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -74,22 +105,24 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	// Watch for changes to ClusterTestSuite
-	err = c.Watch(&source.Kind{Type: &testingv1alpha1.ClusterTestSuite{}}, &handler.EnqueueRequestForObject{})
-	if err != nil {
-		return err
-	}
+	for _, suiteType := range []runtime.Object{&testingv1alpha1.ClusterTestSuite{}, &testingv1alpha1.TestSuite{}} {
+		// Watch for changes to ClusterTestSuite and TestSuite
+		err = c.Watch(&source.Kind{Type: suiteType}, &handler.EnqueueRequestForObject{})
+		if err != nil {
+			return err
+		}
 
-	// Watch for changes to Pods
-	err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{
-		IsController: true,
-		OwnerType:    &testingv1alpha1.ClusterTestSuite{},
-	}, predicate.Funcs{CreateFunc: func(event event.CreateEvent) bool {
-		return false
-	}})
+		// Watch for changes to Pods
+		err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    suiteType,
+		}, predicate.Funcs{CreateFunc: func(event event.CreateEvent) bool {
+			return false
+		}})
 
-	if err != nil {
-		return err
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -97,12 +130,18 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 
 var _ reconcile.Reconciler = &ReconcileTestSuite{}
 
-// ReconcileTestSuite reconciles a ClusterTestSuite object
+// ReconcileTestSuite reconciles ClusterTestSuite and TestSuite objects
 type ReconcileTestSuite struct {
 	client.Client
 	scheme            *runtime.Scheme
 	scheduler         TestScheduler
 	podSvc            TestReporter
+	terminator        TestTerminator
+	logCollector      LogCollector
+	metrics           MetricsRecorder
+	flakiness         FlakinessRecorder
+	retention         RetentionService
+	recorder          EventRecorder
 	statusService     SuiteStatusService
 	definitionService TestDefinitionService
 	log               logr.Logger
@@ -115,18 +154,24 @@ const (
 	throttleTime          = time.Millisecond * 500
 )
 
-// Reconcile reads that state of the cluster for a ClusterTestSuite object and makes changes based on the state read
-// and what is in the ClusterTestSuite.Spec
+// Reconcile reads that state of the cluster for a ClusterTestSuite or TestSuite object and makes changes based on the state read
+// and what is in the Spec. Requests for a ClusterTestSuite have no namespace.
 
 // Automatically generate RBAC rules to allow the Controller to read and write Pods
 // +kubebuilder:rbac:groups=apps,resources=pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=testing.kyma-project.io,resources=clustertestsuites,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=testing.kyma-project.io,resources=clustertestsuites/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=testing.kyma-project.io,resources=testsuites,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=testing.kyma-project.io,resources=testsuites/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=testing.kyma-project.io,resources=testdefinitions/status,verbs=get;update;patch
 func (r *ReconcileTestSuite) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	ctx := context.TODO()
 	r.throttleIfNeeded()
-	// Fetch the ClusterTestSuite suiteCopy
-	suite := &testingv1alpha1.ClusterTestSuite{}
+	// Fetch the ClusterTestSuite or TestSuite
+	suite := r.newSuite(request.NamespacedName)
 	err := r.Get(ctx, request.NamespacedName, suite)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -138,48 +183,106 @@ func (r *ReconcileTestSuite) Reconcile(request reconcile.Request) (reconcile.Res
 		return reconcile.Result{}, err
 	}
 
-	suiteCopy := suite.DeepCopy()
-	logSuite := r.log.WithValues("suite", suiteCopy.Name)
+	suiteCopy := suite.Copy()
+	logSuite := r.log.WithValues("suite", suiteCopy.GetName(), "namespace", suiteCopy.GetNamespace())
 
-	if r.statusService.IsUninitialized(*suiteCopy) {
+	if r.statusService.IsUninitialized(suiteCopy) {
 		logSuite.Info("Initialize suite")
-		testDefs, err := r.definitionService.FindMatching(*suiteCopy)
+		testDefs, err := r.definitionService.FindMatching(suiteCopy)
+		if err != nil {
+			r.recorder.SuiteEvent(suiteCopy, corev1.EventTypeWarning, events.ReasonInitializationFailed, "Cannot find matching test definitions: %s", humanMessage(err))
+			statErr := r.setErrorStatus(ctx, suiteCopy, testingv1alpha1.ReasonErrorOnInitialization, err)
+			return reconcile.Result{}, errors.Wrapf(multierr.Combine(err, statErr), "while looking for matching test definitions for suite [%s]", suiteCopy.GetName())
+		}
+		setup, teardown, err := r.definitionService.FindHooks(suiteCopy)
 		if err != nil {
+			r.recorder.SuiteEvent(suiteCopy, corev1.EventTypeWarning, events.ReasonInitializationFailed, "Cannot find setup or teardown: %s", humanMessage(err))
+			statErr := r.setErrorStatus(ctx, suiteCopy, testingv1alpha1.ReasonErrorOnInitialization, err)
+			return reconcile.Result{}, errors.Wrapf(multierr.Combine(err, statErr), "while looking for setup and teardown of suite [%s]", suiteCopy.GetName())
+		}
+		if err := scheduler.ValidateVolumeMounts(*suiteCopy.GetSpec(), withHooks(testDefs, setup, teardown)); err != nil {
+			r.recorder.SuiteEvent(suiteCopy, corev1.EventTypeWarning, events.ReasonInitializationFailed, "Cannot mount volumes: %s", humanMessage(err))
 			statErr := r.setErrorStatus(ctx, suiteCopy, testingv1alpha1.ReasonErrorOnInitialization, err)
-			return reconcile.Result{}, errors.Wrapf(multierr.Combine(err, statErr), "while looking for matching test definitions for suite [%s]", suiteCopy.Name)
+			return reconcile.Result{}, errors.Wrapf(multierr.Combine(err, statErr), "while validating volume mounts of suite [%s]", suiteCopy.GetName())
 		}
-		currStatus, err := r.statusService.InitializeTests(*suiteCopy, testDefs)
+		currStatus, err := r.statusService.InitializeTests(suiteCopy, testDefs)
 		if err != nil {
-			return reconcile.Result{}, errors.Wrapf(err, "while initializing tests for suite [%s]", suiteCopy.Name)
+			r.recorder.SuiteEvent(suiteCopy, corev1.EventTypeWarning, events.ReasonInitializationFailed, "Cannot initialize tests: %s", humanMessage(err))
+			statErr := r.setErrorStatus(ctx, suiteCopy, testingv1alpha1.ReasonErrorOnInitialization, err)
+			return reconcile.Result{}, errors.Wrapf(multierr.Combine(err, statErr), "while initializing tests for suite [%s]", suiteCopy.GetName())
+		}
+		if err := scheduler.ValidateTestNames(currStatus.Results); err != nil {
+			r.recorder.SuiteEvent(suiteCopy, corev1.EventTypeWarning, events.ReasonInitializationFailed, "Cannot initialize tests: %s", humanMessage(err))
+			statErr := r.setErrorStatus(ctx, suiteCopy, testingv1alpha1.ReasonErrorOnInitialization, err)
+			return reconcile.Result{}, errors.Wrapf(multierr.Combine(err, statErr), "while validating names of tests for suite [%s]", suiteCopy.GetName())
 		}
-		suiteCopy.Status = *currStatus
+		r.statusService.InitializeHooks(currStatus, setup, teardown)
+		suiteCopy.SetStatus(*currStatus)
 		if err := r.Client.Status().Update(ctx, suiteCopy); err != nil {
-			return reconcile.Result{}, errors.Wrapf(err, "while updating status of initialized suite [%s]", suiteCopy.Name)
+			return reconcile.Result{}, errors.Wrapf(err, "while updating status of initialized suite [%s]", suiteCopy.GetName())
 		}
+		r.metrics.Record(suite, suiteCopy)
+		r.recorder.SuiteEvent(suiteCopy, corev1.EventTypeNormal, events.ReasonInitialized, "Suite initialized with %d test(s)", len(currStatus.Results))
+		r.recordIfFinished(suiteCopy)
 		return reconcile.Result{Requeue: true, RequeueAfter: requeueAfterChanges}, nil
 	}
-	if r.statusService.IsFinished(*suiteCopy) {
+	if r.statusService.IsFinished(suiteCopy) {
+		if _, ok := suiteCopy.GetAnnotations()[testingv1alpha1.AnnotationKeyAbort]; ok {
+			if err := r.removeAnnotation(ctx, suiteCopy, testingv1alpha1.AnnotationKeyAbort); err != nil {
+				return reconcile.Result{}, errors.Wrapf(err, "while removing abort annotation from finished suite [%s]", suiteCopy.GetName())
+			}
+			r.recorder.SuiteEvent(suiteCopy, corev1.EventTypeNormal, events.ReasonSuiteAborted, "Nothing to abort, suite is already finished")
+		}
+		if rerun, ok := suiteCopy.GetAnnotations()[testingv1alpha1.AnnotationKeyRerun]; ok {
+			logSuite.Info("Rerun suite", "rerun", rerun)
+			return r.rerun(ctx, suite, suiteCopy, rerun)
+		}
+		if r.retention.IsEnabled(suiteCopy) {
+			return r.cleanUp(ctx, suiteCopy)
+		}
 		logSuite.Info("Do nothing, suite is finished")
 		return reconcile.Result{}, nil
 	}
 
-	updatedStatus, err := r.ensureStatusIsUpToDate(ctx, *suiteCopy)
-	if err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "while ensuring status is up-to-date for suite [%s]", suiteCopy.Name)
+	_, abort := suiteCopy.GetAnnotations()[testingv1alpha1.AnnotationKeyAbort]
+	if abort {
+		logSuite.Info("Abort suite")
 	}
-	suiteCopy.Status = *updatedStatus
-	pod, updatedStatus, err := r.scheduler.TrySchedule(*suiteCopy)
+
+	updatedStatus, pendingEvents, err := r.ensureStatusIsUpToDate(ctx, suiteCopy, abort)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "while scheduling next testing pod for suite [%s]", suiteCopy.Name)
+		return reconcile.Result{}, errors.Wrapf(err, "while ensuring status is up-to-date for suite [%s]", suiteCopy.GetName())
 	}
-	if pod != nil {
-		logSuite.Info("Testing pod created", "podName", pod.Name, "podNs", pod.Namespace)
-		suiteCopy.Status = *updatedStatus
+	suiteCopy.SetStatus(*updatedStatus)
+
+	var pod *corev1.Pod
+	if r.statusService.IsFinished(suiteCopy) {
+		logSuite.Info("Suite finished, no more testing pods will be scheduled")
+	} else {
+		pod, updatedStatus, err = r.scheduler.TrySchedule(suiteCopy)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "while scheduling next testing pod for suite [%s]", suiteCopy.GetName())
+		}
+		if pod != nil {
+			logSuite.Info("Testing pod created", "podName", pod.Name, "podNs", pod.Namespace)
+			suiteCopy.SetStatus(*updatedStatus)
+		}
 	}
 
 	if err := r.Client.Status().Update(ctx, suiteCopy); err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "while updating status of running suite [%s]", suiteCopy.Name)
+		return reconcile.Result{}, errors.Wrapf(err, "while updating status of running suite [%s]", suiteCopy.GetName())
+	}
+	pendingEvents.Record()
+	if abort {
+		// the annotation is removed only once the aborted status is saved, otherwise the abort could be lost.
+		// Aborting the suite again is harmless if removing the annotation fails.
+		if err := r.removeAnnotation(ctx, suiteCopy, testingv1alpha1.AnnotationKeyAbort); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "while removing abort annotation from suite [%s]", suiteCopy.GetName())
+		}
 	}
+	r.metrics.Record(suite, suiteCopy)
+	r.recordIfFinished(suiteCopy)
+	r.recordHistoryIfFinished(ctx, suiteCopy)
 
 	if pod != nil {
 		// requeue almost immediately to try schedule other tests
@@ -204,42 +307,207 @@ func (r *ReconcileTestSuite) throttleIfNeeded() {
 
 }
 
-func (r *ReconcileTestSuite) ensureStatusIsUpToDate(ctx context.Context, suite testingv1alpha1.ClusterTestSuite) (*testingv1alpha1.TestSuiteStatus, error) {
+// ensureStatusIsUpToDate updates the status with phases of testing pods. If the suite is aborted,
+// executions in progress are interrupted and their testing pods are deleted.
+func (r *ReconcileTestSuite) ensureStatusIsUpToDate(ctx context.Context, suite testingv1alpha1.GenericTestSuite, abort bool) (*testingv1alpha1.TestSuiteStatus, *status.PendingEvents, error) {
+	pods, err := r.podSvc.GetPodsForSuite(ctx, suite)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stat, pendingEvents, err := r.statusService.EnsureStatusIsUpToDate(suite, pods)
+	if err != nil {
+		return nil, nil, err
+	}
+	updated := suite.Copy()
+	updated.SetStatus(*stat)
+	if abort {
+		stat = r.statusService.AbortSuite(updated)
+		updated.SetStatus(*stat)
+	}
+	// logs have to be collected before interrupted testing pods are deleted
+	stat = r.logCollector.CollectLogs(ctx, updated, pods)
+	updated.SetStatus(*stat)
+	if err := r.terminator.TerminateInterrupted(ctx, updated, pods); err != nil {
+		return nil, nil, errors.Wrap(err, "while terminating interrupted testing pods")
+	}
+	return stat, pendingEvents, nil
+}
+
+// cleanUp deletes testing pods and the suite itself according to the retention defined in the suite.
+// The suite is requeued until all TTLs expire.
+func (r *ReconcileTestSuite) cleanUp(ctx context.Context, suite testingv1alpha1.GenericTestSuite) (reconcile.Result, error) {
 	pods, err := r.podSvc.GetPodsForSuite(ctx, suite)
 	if err != nil {
-		return nil, err
+		return reconcile.Result{}, errors.Wrapf(err, "while getting testing pods of finished suite [%s]", suite.GetName())
+	}
+	requeueAfter, err := r.retention.CleanUp(ctx, suite, pods)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "while cleaning up finished suite [%s]", suite.GetName())
+	}
+	if requeueAfter > 0 {
+		return reconcile.Result{Requeue: true, RequeueAfter: requeueAfter}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// rerun reopens a finished suite on user request. The annotation with the request is removed first,
+// so the suite is rerun only once.
+func (r *ReconcileTestSuite) rerun(ctx context.Context, prev, suite testingv1alpha1.GenericTestSuite, rerun string) (reconcile.Result, error) {
+	if err := r.removeAnnotation(ctx, suite, testingv1alpha1.AnnotationKeyRerun); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "while removing rerun annotation from suite [%s]", suite.GetName())
+	}
+
+	if rerun != testingv1alpha1.RerunFailed {
+		r.recorder.SuiteEvent(suite, corev1.EventTypeWarning, events.ReasonInvalidRerun, "Cannot rerun suite, unsupported value [%s] of annotation [%s], supported values: [%s]", rerun, testingv1alpha1.AnnotationKeyRerun, testingv1alpha1.RerunFailed)
+		return reconcile.Result{}, nil
+	}
+	stat, count := r.statusService.RerunFailedTests(suite)
+	if count == 0 {
+		r.recorder.SuiteEvent(suite, corev1.EventTypeNormal, events.ReasonRerun, "Nothing to rerun, suite has no failed tests")
+		return reconcile.Result{}, nil
+	}
+	suite.SetStatus(*stat)
+	if err := r.Client.Status().Update(ctx, suite); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "while updating status of rerun suite [%s]", suite.GetName())
+	}
+	r.metrics.Record(prev, suite)
+	r.recorder.SuiteEvent(suite, corev1.EventTypeNormal, events.ReasonRerun, "Rerunning %d failed test(s)", count)
+	return reconcile.Result{Requeue: true, RequeueAfter: requeueAfterChanges}, nil
+}
+
+// removeAnnotation removes the annotation with a user request from the suite, so the request is handled only once
+func (r *ReconcileTestSuite) removeAnnotation(ctx context.Context, suite testingv1alpha1.GenericTestSuite, key string) error {
+	annotations := make(map[string]string)
+	for k, v := range suite.GetAnnotations() {
+		if k != key {
+			annotations[k] = v
+		}
+	}
+	suite.SetAnnotations(annotations)
+	return r.Client.Update(ctx, suite)
+}
+
+// newSuite returns an empty suite of the kind the request refers to
+func (r *ReconcileTestSuite) newSuite(key types.NamespacedName) testingv1alpha1.GenericTestSuite {
+	if key.Namespace == "" {
+		return &testingv1alpha1.ClusterTestSuite{}
+	}
+	return &testingv1alpha1.TestSuite{}
+}
+
+// recordIfFinished records an Event with the final condition of the suite
+func (r *ReconcileTestSuite) recordIfFinished(suite testingv1alpha1.GenericTestSuite) {
+	if !r.statusService.IsFinished(suite) {
+		return
+	}
+	for _, cond := range suite.GetStatus().Conditions {
+		if cond.Status != testingv1alpha1.StatusTrue {
+			continue
+		}
+		msg := cond.Message
+		if msg == "" {
+			msg = fmt.Sprintf("Suite finished with condition [%s]", cond.Type)
+		}
+		switch cond.Type {
+		case testingv1alpha1.SuiteSucceeded:
+			r.recorder.SuiteEvent(suite, corev1.EventTypeNormal, events.ReasonSuiteSucceeded, "%s", msg)
+		case testingv1alpha1.SuiteFailed:
+			r.recorder.SuiteEvent(suite, corev1.EventTypeWarning, events.ReasonSuiteFailed, "%s", msg)
+		case testingv1alpha1.SuiteError:
+			r.recorder.SuiteEvent(suite, corev1.EventTypeWarning, events.ReasonSuiteError, "%s", msg)
+		case testingv1alpha1.SuiteAborted:
+			r.recorder.SuiteEvent(suite, corev1.EventTypeWarning, events.ReasonSuiteAborted, "%s", msg)
+		}
 	}
+}
 
-	return r.statusService.EnsureStatusIsUpToDate(suite, pods)
+// recordHistoryIfFinished adds results of a finished suite to the history of TestDefinitions.
+// Errors are only logged, because a finished suite is not reconciled anymore.
+func (r *ReconcileTestSuite) recordHistoryIfFinished(ctx context.Context, suite testingv1alpha1.GenericTestSuite) {
+	if !r.statusService.IsFinished(suite) {
+		return
+	}
+	if err := r.flakiness.RecordSuite(ctx, suite); err != nil {
+		r.log.Error(err, "Cannot record history of tests", "suite", suite.GetName(), "namespace", suite.GetNamespace())
+	}
 }
 
-func (r *ReconcileTestSuite) setErrorStatus(ctx context.Context, suite *testingv1alpha1.ClusterTestSuite, reason string, err error) error {
+func (r *ReconcileTestSuite) setErrorStatus(ctx context.Context, suite testingv1alpha1.GenericTestSuite, reason string, err error) error {
 	msg := ""
 	if hErr, ok := humanerr.GetHumanReadableError(err); ok {
 		msg = hErr.Message
 	}
 
-	r.statusService.SetSuiteCondition(&suite.Status, testingv1alpha1.SuiteError, reason, msg)
+	r.statusService.SetSuiteCondition(suite.GetStatus(), testingv1alpha1.SuiteError, reason, msg)
 	return r.Client.Status().Update(ctx, suite)
 }
 
+// humanMessage returns the human-readable message of the error if available
+func humanMessage(err error) string {
+	if hErr, ok := humanerr.GetHumanReadableError(err); ok {
+		return hErr.Message
+	}
+	return err.Error()
+}
+
+// withHooks returns TestDefinitions of tests together with the setup and teardown, if they are defined
+func withHooks(defs []testingv1alpha1.TestDefinition, setup, teardown *testingv1alpha1.TestDefinition) []testingv1alpha1.TestDefinition {
+	out := append([]testingv1alpha1.TestDefinition(nil), defs...)
+	for _, hook := range []*testingv1alpha1.TestDefinition{setup, teardown} {
+		if hook != nil {
+			out = append(out, *hook)
+		}
+	}
+	return out
+}
+
 // dependencies
 type TestScheduler interface {
-	TrySchedule(suite testingv1alpha1.ClusterTestSuite) (*corev1.Pod, *testingv1alpha1.TestSuiteStatus, error)
+	TrySchedule(suite testingv1alpha1.GenericTestSuite) (*corev1.Pod, *testingv1alpha1.TestSuiteStatus, error)
 }
 
 type TestReporter interface {
-	GetPodsForSuite(ctx context.Context, suite testingv1alpha1.ClusterTestSuite) ([]corev1.Pod, error)
+	GetPodsForSuite(ctx context.Context, suite testingv1alpha1.GenericTestSuite) ([]corev1.Pod, error)
+}
+
+type TestTerminator interface {
+	TerminateInterrupted(ctx context.Context, suite testingv1alpha1.GenericTestSuite, pods []corev1.Pod) error
+}
+
+type FlakinessRecorder interface {
+	RecordSuite(ctx context.Context, suite testingv1alpha1.GenericTestSuite) error
+}
+
+type RetentionService interface {
+	IsEnabled(suite testingv1alpha1.GenericTestSuite) bool
+	CleanUp(ctx context.Context, suite testingv1alpha1.GenericTestSuite, pods []corev1.Pod) (time.Duration, error)
+}
+
+type LogCollector interface {
+	CollectLogs(ctx context.Context, suite testingv1alpha1.GenericTestSuite, pods []corev1.Pod) *testingv1alpha1.TestSuiteStatus
+}
+
+type MetricsRecorder interface {
+	Record(prev, curr testingv1alpha1.GenericTestSuite)
+}
+
+type EventRecorder interface {
+	SuiteEvent(suite testingv1alpha1.GenericTestSuite, eventType, reason, messageFmt string, args ...interface{})
 }
 
 type SuiteStatusService interface {
-	EnsureStatusIsUpToDate(suite testingv1alpha1.ClusterTestSuite, pods []corev1.Pod) (*testingv1alpha1.TestSuiteStatus, error)
-	InitializeTests(suite testingv1alpha1.ClusterTestSuite, defs []testingv1alpha1.TestDefinition) (*testingv1alpha1.TestSuiteStatus, error)
-	IsUninitialized(suite testingv1alpha1.ClusterTestSuite) bool
-	IsFinished(suite testingv1alpha1.ClusterTestSuite) bool
+	EnsureStatusIsUpToDate(suite testingv1alpha1.GenericTestSuite, pods []corev1.Pod) (*testingv1alpha1.TestSuiteStatus, *status.PendingEvents, error)
+	InitializeTests(suite testingv1alpha1.GenericTestSuite, defs []testingv1alpha1.TestDefinition) (*testingv1alpha1.TestSuiteStatus, error)
+	InitializeHooks(stat *testingv1alpha1.TestSuiteStatus, setup, teardown *testingv1alpha1.TestDefinition)
+	IsUninitialized(suite testingv1alpha1.GenericTestSuite) bool
+	IsFinished(suite testingv1alpha1.GenericTestSuite) bool
+	RerunFailedTests(suite testingv1alpha1.GenericTestSuite) (*testingv1alpha1.TestSuiteStatus, int)
+	AbortSuite(suite testingv1alpha1.GenericTestSuite) *testingv1alpha1.TestSuiteStatus
 	SetSuiteCondition(stat *testingv1alpha1.TestSuiteStatus, tp testingv1alpha1.TestSuiteConditionType, reason, msg string)
 }
 
 type TestDefinitionService interface {
-	FindMatching(suite testingv1alpha1.ClusterTestSuite) ([]testingv1alpha1.TestDefinition, error)
+	FindMatching(suite testingv1alpha1.GenericTestSuite) ([]testingv1alpha1.TestDefinition, error)
+	FindHooks(suite testingv1alpha1.GenericTestSuite) (*testingv1alpha1.TestDefinition, *testingv1alpha1.TestDefinition, error)
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/go-logr/logr"
+	"github.com/kyma-incubator/octopus/pkg/config"
 	"github.com/kyma-incubator/octopus/pkg/repeat"
 	"github.com/stretchr/testify/require"
 	"k8s.io/api/core/v1"
@@ -75,7 +76,7 @@ func TestReconcileClusterTestSuite(t *testing.T) {
 
 		defer cleanupK8sObject(ctx, c, suite)
 
-		require.NoError(t, add(mgr, newReconciler(mgr)))
+		require.NoError(t, Add(mgr, config.Config{}))
 		stopMgr, mgrStopped := StartTestManager(t, mgr)
 
 		defer func() {
@@ -144,7 +145,7 @@ func TestReconcileClusterTestSuite(t *testing.T) {
 		require.NoError(t, err)
 		defer cleanupK8sObject(ctx, c, suite)
 
-		require.NoError(t, add(mgr, newReconciler(mgr)))
+		require.NoError(t, Add(mgr, config.Config{}))
 		stopMgr, mgrStopped := StartTestManager(t, mgr)
 
 		defer func() {
@@ -215,7 +216,7 @@ func TestReconcileClusterTestSuite(t *testing.T) {
 		require.NoError(t, err)
 		defer cleanupK8sObject(ctx, c, suite)
 
-		require.NoError(t, add(mgr, newReconciler(mgr)))
+		require.NoError(t, Add(mgr, config.Config{}))
 		stopMgr, mgrStopped := StartTestManager(t, mgr)
 		defer func() {
 			close(stopMgr)
@@ -273,7 +274,7 @@ func TestReconcileClusterTestSuite(t *testing.T) {
 
 		ctx := context.Background()
 
-		require.NoError(t, add(mgr, newReconciler(mgr)))
+		require.NoError(t, Add(mgr, config.Config{}))
 		stopMgr, mgrStopped := StartTestManager(t, mgr)
 
 		defer func() {
@@ -340,7 +341,7 @@ func TestReconcileClusterTestSuite(t *testing.T) {
 		testNs := generateTestNs()
 		ctx := context.Background()
 
-		require.NoError(t, add(mgr, newReconciler(mgr)))
+		require.NoError(t, Add(mgr, config.Config{}))
 		stopMgr, mgrStopped := StartTestManager(t, mgr)
 
 		defer func() {
@@ -415,6 +416,78 @@ func TestReconcileClusterTestSuite(t *testing.T) {
 
 		assertThatPodsCreatedSequentially(t, podReconciler.getAppliedChanges())
 	})
+
+	t.Run("namespaced suite", func(t *testing.T) {
+		// GIVEN
+		// Setup the Manager and Controller
+		mgr, err := manager.New(cfg, manager.Options{})
+		require.NoError(t, err)
+		c := mgr.GetClient()
+
+		testNs := generateTestNs()
+		otherNs := generateTestNs()
+		ctx := context.Background()
+
+		require.NoError(t, Add(mgr, config.Config{}))
+		stopMgr, mgrStopped := StartTestManager(t, mgr)
+
+		defer func() {
+			close(stopMgr)
+			mgrStopped.Wait()
+		}()
+
+		logf.SetLogger(logf.ZapLogger(false))
+
+		_, err = startMockPodController(mgr, 0)
+		require.NoError(t, err)
+
+		// WHEN
+		for _, nsName := range []string{testNs, otherNs} {
+			ns := &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nsName,
+				},
+			}
+			err = c.Create(ctx, ns)
+			require.NoError(t, err)
+			defer cleanupK8sObject(ctx, c, ns)
+		}
+
+		testA := getConcurrentTest("test-a", testNs)
+		err = c.Create(ctx, testA)
+		require.NoError(t, err)
+		defer cleanupK8sObject(ctx, c, testA)
+
+		testB := getConcurrentTest("test-b", otherNs)
+		err = c.Create(ctx, testB)
+		require.NoError(t, err)
+		defer cleanupK8sObject(ctx, c, testB)
+
+		suite := &testingv1alpha1.TestSuite{
+			ObjectMeta: metav1.ObjectMeta{Name: "suite-namespaced", Namespace: testNs},
+			Spec: testingv1alpha1.TestSuiteSpec{
+				Concurrency: 1,
+				Count:       1,
+			},
+		}
+		err = c.Create(ctx, suite)
+		require.NoError(t, err)
+		defer cleanupK8sObject(ctx, c, suite)
+
+		// THEN
+		repeat.AssertFuncAtMost(t, func() error {
+			return checkIfNamespacedSuiteIsSucceeded(ctx, c, testNs, "suite-namespaced")
+		}, defaultAssertionTimeout)
+
+		repeat.AssertFuncAtMost(t, func() error {
+			return checkIfPodsWereCreated(ctx, c, testNs, []string{
+				"oct-np-suite-namespaced-test-a-0"})
+		}, defaultAssertionTimeout)
+
+		repeat.AssertFuncAtMost(t, func() error {
+			return checkIfPodsWereCreated(ctx, c, otherNs, []string{})
+		}, defaultAssertionTimeout)
+	})
 }
 
 func assertThatPodsCreatedConcurrently(t *testing.T, appliedChanges []podStatusChanges) {
@@ -453,9 +526,20 @@ func checkIfsuiteIsSucceeded(ctx context.Context, reader client.Reader, suiteNam
 	if err := reader.Get(ctx, types.NamespacedName{Name: suiteName}, &actualSuite); err != nil {
 		return err
 	}
+	return checkIfStatusIsSucceeded(actualSuite.Status)
+}
+
+func checkIfNamespacedSuiteIsSucceeded(ctx context.Context, reader client.Reader, ns, suiteName string) error {
+	var actualSuite testingv1alpha1.TestSuite
+	if err := reader.Get(ctx, types.NamespacedName{Name: suiteName, Namespace: ns}, &actualSuite); err != nil {
+		return err
+	}
+	return checkIfStatusIsSucceeded(actualSuite.Status)
+}
 
+func checkIfStatusIsSucceeded(status testingv1alpha1.TestSuiteStatus) error {
 	succeeded := false
-	for _, cond := range actualSuite.Status.Conditions {
+	for _, cond := range status.Conditions {
 		if cond.Type == testingv1alpha1.SuiteSucceeded && cond.Status == testingv1alpha1.StatusTrue {
 			succeeded = true
 		} else if cond.Status == testingv1alpha1.StatusTrue {
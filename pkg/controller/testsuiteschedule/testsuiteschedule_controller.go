@@ -0,0 +1,294 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testsuiteschedule
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	testingv1alpha1 "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/config"
+	"github.com/kyma-incubator/octopus/pkg/events"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// suiteNameTimeFormat is appended to the schedule name to get the name of a created suite
+	suiteNameTimeFormat = "200601021504"
+	// maxMissedWindow limits how far back missed schedule times are looked for, e.g. after the controller was down
+	maxMissedWindow = 24 * time.Hour
+)
+
+// Add creates a new ClusterTestSuiteSchedule Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, _ config.Config) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileTestSuiteSchedule{
+		Client:      mgr.GetClient(),
+		scheme:      mgr.GetScheme(),
+		recorder:    mgr.GetEventRecorderFor("octopus"),
+		nowProvider: time.Now,
+		log:         logf.Log.WithName("ctss_controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("testsuiteschedule-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterTestSuiteSchedule
+	err = c.Watch(&source.Kind{Type: &testingv1alpha1.ClusterTestSuiteSchedule{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterTestSuites created by the schedule
+	return c.Watch(&source.Kind{Type: &testingv1alpha1.ClusterTestSuite{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &testingv1alpha1.ClusterTestSuiteSchedule{},
+	})
+}
+
+var _ reconcile.Reconciler = &ReconcileTestSuiteSchedule{}
+
+// ReconcileTestSuiteSchedule reconciles a ClusterTestSuiteSchedule object
+type ReconcileTestSuiteSchedule struct {
+	client.Client
+	scheme      *runtime.Scheme
+	recorder    record.EventRecorder
+	nowProvider func() time.Time
+	log         logr.Logger
+}
+
+// Reconcile creates ClusterTestSuites according to the schedule and removes the oldest finished ones.
+// +kubebuilder:rbac:groups=testing.kyma-project.io,resources=clustertestsuiteschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=testing.kyma-project.io,resources=clustertestsuiteschedules/status,verbs=get;update;patch
+func (r *ReconcileTestSuiteSchedule) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx := context.TODO()
+	var schedule testingv1alpha1.ClusterTestSuiteSchedule
+	if err := r.Get(ctx, request.NamespacedName, &schedule); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Created suites are garbage collected together with the schedule
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	logSchedule := r.log.WithValues("schedule", schedule.Name)
+	spec := schedule.Spec.DeepCopy()
+	spec.SetDefaults()
+	now := r.nowProvider()
+
+	active, succeeded, failed, err := r.getSuites(ctx, schedule)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "while getting suites of schedule [%s]", schedule.Name)
+	}
+	if err := r.deleteOldest(ctx, succeeded, int(*spec.SuccessfulSuitesHistoryLimit)); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "while removing succeeded suites of schedule [%s]", schedule.Name)
+	}
+	if err := r.deleteOldest(ctx, failed, int(*spec.FailedSuitesHistoryLimit)); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "while removing failed suites of schedule [%s]", schedule.Name)
+	}
+
+	updated := schedule.DeepCopy()
+	updated.Status.Active = suiteNames(active)
+
+	if spec.Suspend {
+		logSchedule.Info("Schedule is suspended")
+		return reconcile.Result{}, r.updateStatus(ctx, schedule, *updated)
+	}
+
+	sched, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		// retrying does not help, the schedule is reconciled again once it is fixed
+		logSchedule.Error(err, "Invalid schedule", "schedule", spec.Schedule)
+		r.recorder.Eventf(&schedule, corev1.EventTypeWarning, events.ReasonInvalidSchedule, "Cannot parse schedule [%s]: %s", spec.Schedule, err)
+		return reconcile.Result{}, r.updateStatus(ctx, schedule, *updated)
+	}
+
+	scheduledTime := r.getMostRecentScheduleTime(schedule, sched, now)
+	requeue := reconcile.Result{RequeueAfter: sched.Next(now).Sub(now)}
+	if scheduledTime == nil {
+		return requeue, r.updateStatus(ctx, schedule, *updated)
+	}
+
+	if len(active) > 0 {
+		switch spec.ConcurrencyPolicy {
+		case testingv1alpha1.ForbidConcurrent:
+			// the suite is created once the active ones finish, which triggers reconciliation
+			logSchedule.Info("Suite not created, previous suite is still running", "active", updated.Status.Active)
+			return requeue, r.updateStatus(ctx, schedule, *updated)
+		case testingv1alpha1.ReplaceConcurrent:
+			for _, suite := range active {
+				if err := r.Delete(ctx, &suite, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !k8serrors.IsNotFound(err) {
+					return reconcile.Result{}, errors.Wrapf(err, "while deleting running suite [%s]", suite.Name)
+				}
+				r.recorder.Eventf(&schedule, corev1.EventTypeNormal, events.ReasonSuiteReplaced, "Deleted running suite [%s]", suite.Name)
+			}
+			updated.Status.Active = nil
+		}
+	}
+
+	suite, err := r.createSuite(ctx, schedule, *spec, *scheduledTime)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "while creating suite for schedule [%s]", schedule.Name)
+	}
+	logSchedule.Info("Suite created", "suite", suite.Name)
+	r.recorder.Eventf(&schedule, corev1.EventTypeNormal, events.ReasonSuiteCreated, "Created suite [%s]", suite.Name)
+
+	updated.Status.Active = append(updated.Status.Active, suite.Name)
+	updated.Status.LastScheduleTime = &metav1.Time{Time: *scheduledTime}
+	return requeue, r.updateStatus(ctx, schedule, *updated)
+}
+
+// getSuites returns suites created by the schedule, split into active, succeeded and failed ones
+func (r *ReconcileTestSuiteSchedule) getSuites(ctx context.Context, schedule testingv1alpha1.ClusterTestSuiteSchedule) (active, succeeded, failed []testingv1alpha1.ClusterTestSuite, err error) {
+	var list testingv1alpha1.ClusterTestSuiteList
+	if err := r.List(ctx, &list, client.MatchingLabels{testingv1alpha1.LabelKeyScheduleName: schedule.Name}); err != nil {
+		return nil, nil, nil, err
+	}
+	for _, suite := range list.Items {
+		if !metav1.IsControlledBy(&suite, &schedule) {
+			continue
+		}
+		switch finishedCondition(suite.Status) {
+		case testingv1alpha1.SuiteSucceeded:
+			succeeded = append(succeeded, suite)
+		case testingv1alpha1.SuiteFailed, testingv1alpha1.SuiteError, testingv1alpha1.SuiteAborted:
+			failed = append(failed, suite)
+		default:
+			active = append(active, suite)
+		}
+	}
+	return active, succeeded, failed, nil
+}
+
+// deleteOldest removes finished suites that exceed the history limit
+func (r *ReconcileTestSuiteSchedule) deleteOldest(ctx context.Context, suites []testingv1alpha1.ClusterTestSuite, limit int) error {
+	if len(suites) <= limit {
+		return nil
+	}
+	sort.Slice(suites, func(i, j int) bool {
+		return suites[i].CreationTimestamp.Before(&suites[j].CreationTimestamp)
+	})
+	for _, suite := range suites[:len(suites)-limit] {
+		if err := r.Delete(ctx, &suite, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "while deleting suite [%s]", suite.Name)
+		}
+	}
+	return nil
+}
+
+// getMostRecentScheduleTime returns the latest schedule time that has not been handled yet, or nil if there is no such time
+func (r *ReconcileTestSuiteSchedule) getMostRecentScheduleTime(schedule testingv1alpha1.ClusterTestSuiteSchedule, sched cron.Schedule, now time.Time) *time.Time {
+	earliest := schedule.CreationTimestamp.Time
+	if schedule.Status.LastScheduleTime != nil {
+		earliest = schedule.Status.LastScheduleTime.Time
+	}
+	if window := now.Add(-maxMissedWindow); earliest.Before(window) {
+		earliest = window
+	}
+
+	var out *time.Time
+	for t := sched.Next(earliest); !t.After(now); t = sched.Next(t) {
+		scheduled := t
+		out = &scheduled
+	}
+	return out
+}
+
+func (r *ReconcileTestSuiteSchedule) createSuite(ctx context.Context, schedule testingv1alpha1.ClusterTestSuiteSchedule, spec testingv1alpha1.ClusterTestSuiteScheduleSpec, scheduledTime time.Time) (*testingv1alpha1.ClusterTestSuite, error) {
+	suite := &testingv1alpha1.ClusterTestSuite{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: schedule.Name + "-" + scheduledTime.UTC().Format(suiteNameTimeFormat),
+			Labels: map[string]string{
+				testingv1alpha1.LabelKeyScheduleName: schedule.Name,
+			},
+		},
+		Spec: *spec.Template.DeepCopy(),
+	}
+	if err := controllerutil.SetControllerReference(&schedule, suite, r.scheme); err != nil {
+		return nil, errors.Wrapf(err, "while setting controller reference, schedule: [%s], suite: [%s]", schedule.Name, suite.Name)
+	}
+	if err := r.Create(ctx, suite); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	return suite, nil
+}
+
+func (r *ReconcileTestSuiteSchedule) updateStatus(ctx context.Context, prev, curr testingv1alpha1.ClusterTestSuiteSchedule) error {
+	if reflect.DeepEqual(prev.Status, curr.Status) {
+		return nil
+	}
+	if err := r.Status().Update(ctx, &curr); err != nil {
+		return errors.Wrapf(err, "while updating status of schedule [%s]", curr.Name)
+	}
+	return nil
+}
+
+func suiteNames(suites []testingv1alpha1.ClusterTestSuite) []string {
+	var out []string
+	for _, s := range suites {
+		out = append(out, s.Name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// finishedCondition returns the condition of a finished suite, or an empty string if the suite is still running.
+// Suites that failed on initialization are retried, so they are not finished. Suites with the teardown in progress
+// are not finished either.
+func finishedCondition(stat testingv1alpha1.TestSuiteStatus) testingv1alpha1.TestSuiteConditionType {
+	if stat.Teardown != nil && stat.Teardown.Status != testingv1alpha1.TestSucceeded && stat.Teardown.Status != testingv1alpha1.TestFailed &&
+		stat.Teardown.Status != testingv1alpha1.TestSkipped {
+		return ""
+	}
+	for _, cond := range stat.Conditions {
+		if cond.Status != testingv1alpha1.StatusTrue {
+			continue
+		}
+		switch {
+		case cond.Type == testingv1alpha1.SuiteSucceeded, cond.Type == testingv1alpha1.SuiteFailed, cond.Type == testingv1alpha1.SuiteAborted:
+			return cond.Type
+		case cond.Type == testingv1alpha1.SuiteError && cond.Reason != testingv1alpha1.ReasonErrorOnInitialization:
+			return cond.Type
+		}
+	}
+	return ""
+}
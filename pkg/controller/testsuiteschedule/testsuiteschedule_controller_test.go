@@ -0,0 +1,243 @@
+package testsuiteschedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testingv1alpha1 "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var createdAt = time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func TestReconcileCreatesSuite(t *testing.T) {
+	t.Run("creates suite for the most recent schedule time", func(t *testing.T) {
+		// GIVEN
+		schedule := givenSchedule(testingv1alpha1.ForbidConcurrent)
+		sut, cli, recorder := givenReconciler(t, createdAt.Add(2*time.Hour+5*time.Minute), schedule)
+
+		// WHEN
+		res, err := sut.Reconcile(givenRequest())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 55*time.Minute, res.RequeueAfter)
+		suites := getSuites(t, cli)
+		require.Len(t, suites, 1)
+		suite := suites[0]
+		assert.Equal(t, "nightly-201901011200", suite.Name)
+		assert.Equal(t, "nightly", suite.Labels[testingv1alpha1.LabelKeyScheduleName])
+		assert.Equal(t, int64(3), suite.Spec.Count)
+		assert.True(t, metav1.IsControlledBy(&suite, schedule))
+
+		actual := getSchedule(t, cli)
+		assert.Equal(t, []string{"nightly-201901011200"}, actual.Status.Active)
+		require.NotNil(t, actual.Status.LastScheduleTime)
+		assert.True(t, createdAt.Add(2*time.Hour).Equal(actual.Status.LastScheduleTime.Time))
+		assert.Equal(t, "Normal SuiteCreated Created suite [nightly-201901011200]", <-recorder.Events)
+	})
+
+	t.Run("does nothing before the next schedule time", func(t *testing.T) {
+		// GIVEN
+		schedule := givenSchedule(testingv1alpha1.ForbidConcurrent)
+		sut, cli, _ := givenReconciler(t, createdAt.Add(20*time.Minute), schedule)
+
+		// WHEN
+		res, err := sut.Reconcile(givenRequest())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 40*time.Minute, res.RequeueAfter)
+		assert.Empty(t, getSuites(t, cli))
+	})
+
+	t.Run("does nothing if schedule is suspended", func(t *testing.T) {
+		// GIVEN
+		schedule := givenSchedule(testingv1alpha1.ForbidConcurrent)
+		schedule.Spec.Suspend = true
+		sut, cli, _ := givenReconciler(t, createdAt.Add(2*time.Hour), schedule)
+
+		// WHEN
+		res, err := sut.Reconcile(givenRequest())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, res)
+		assert.Empty(t, getSuites(t, cli))
+	})
+
+	t.Run("records event on invalid schedule", func(t *testing.T) {
+		// GIVEN
+		schedule := givenSchedule(testingv1alpha1.ForbidConcurrent)
+		schedule.Spec.Schedule = "every day"
+		sut, cli, recorder := givenReconciler(t, createdAt.Add(2*time.Hour), schedule)
+
+		// WHEN
+		_, err := sut.Reconcile(givenRequest())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Empty(t, getSuites(t, cli))
+		assert.Contains(t, <-recorder.Events, "Warning InvalidSchedule Cannot parse schedule [every day]")
+	})
+}
+
+func TestReconcileConcurrencyPolicy(t *testing.T) {
+	now := createdAt.Add(2*time.Hour + time.Minute)
+
+	t.Run("Forbid", func(t *testing.T) {
+		// GIVEN
+		schedule := givenSchedule(testingv1alpha1.ForbidConcurrent)
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: createdAt.Add(time.Hour)}
+		running := givenSuite(schedule, "nightly-201901011100", createdAt.Add(time.Hour), "")
+		sut, cli, _ := givenReconciler(t, now, schedule, running)
+
+		// WHEN
+		_, err := sut.Reconcile(givenRequest())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"nightly-201901011100"}, suiteNamesOf(getSuites(t, cli)))
+		assert.Equal(t, []string{"nightly-201901011100"}, getSchedule(t, cli).Status.Active)
+	})
+
+	t.Run("Allow", func(t *testing.T) {
+		// GIVEN
+		schedule := givenSchedule(testingv1alpha1.AllowConcurrent)
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: createdAt.Add(time.Hour)}
+		running := givenSuite(schedule, "nightly-201901011100", createdAt.Add(time.Hour), "")
+		sut, cli, _ := givenReconciler(t, now, schedule, running)
+
+		// WHEN
+		_, err := sut.Reconcile(givenRequest())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"nightly-201901011100", "nightly-201901011200"}, suiteNamesOf(getSuites(t, cli)))
+		assert.Equal(t, []string{"nightly-201901011100", "nightly-201901011200"}, getSchedule(t, cli).Status.Active)
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		// GIVEN
+		schedule := givenSchedule(testingv1alpha1.ReplaceConcurrent)
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: createdAt.Add(time.Hour)}
+		running := givenSuite(schedule, "nightly-201901011100", createdAt.Add(time.Hour), "")
+		sut, cli, _ := givenReconciler(t, now, schedule, running)
+
+		// WHEN
+		_, err := sut.Reconcile(givenRequest())
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []string{"nightly-201901011200"}, suiteNamesOf(getSuites(t, cli)))
+		assert.Equal(t, []string{"nightly-201901011200"}, getSchedule(t, cli).Status.Active)
+	})
+}
+
+func TestReconcileRemovesHistory(t *testing.T) {
+	// GIVEN
+	schedule := givenSchedule(testingv1alpha1.ForbidConcurrent)
+	successfulLimit, failedLimit := int32(2), int32(1)
+	schedule.Spec.SuccessfulSuitesHistoryLimit = &successfulLimit
+	schedule.Spec.FailedSuitesHistoryLimit = &failedLimit
+	schedule.Status.LastScheduleTime = &metav1.Time{Time: createdAt.Add(5 * time.Hour)}
+	sut, cli, _ := givenReconciler(t, createdAt.Add(5*time.Hour+time.Minute), schedule,
+		givenSuite(schedule, "nightly-1", createdAt.Add(1*time.Hour), testingv1alpha1.SuiteSucceeded),
+		givenSuite(schedule, "nightly-2", createdAt.Add(2*time.Hour), testingv1alpha1.SuiteFailed),
+		givenSuite(schedule, "nightly-3", createdAt.Add(3*time.Hour), testingv1alpha1.SuiteSucceeded),
+		givenSuite(schedule, "nightly-4", createdAt.Add(4*time.Hour), testingv1alpha1.SuiteError),
+		givenSuite(schedule, "nightly-5", createdAt.Add(5*time.Hour), testingv1alpha1.SuiteSucceeded),
+	)
+
+	// WHEN
+	_, err := sut.Reconcile(givenRequest())
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nightly-3", "nightly-4", "nightly-5"}, suiteNamesOf(getSuites(t, cli)))
+}
+
+func givenReconciler(t *testing.T, now time.Time, objects ...runtime.Object) (*ReconcileTestSuiteSchedule, client.Client, *record.FakeRecorder) {
+	sch := runtime.NewScheme()
+	require.NoError(t, testingv1alpha1.AddToScheme(sch))
+	cli := fake.NewFakeClientWithScheme(sch, objects...)
+	recorder := record.NewFakeRecorder(10)
+	return &ReconcileTestSuiteSchedule{
+		Client:      cli,
+		scheme:      sch,
+		recorder:    recorder,
+		nowProvider: func() time.Time { return now },
+		log:         logf.Log,
+	}, cli, recorder
+}
+
+func givenSchedule(policy testingv1alpha1.ConcurrencyPolicy) *testingv1alpha1.ClusterTestSuiteSchedule {
+	return &testingv1alpha1.ClusterTestSuiteSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "nightly",
+			UID:               "nightly-uid",
+			CreationTimestamp: metav1.Time{Time: createdAt},
+		},
+		Spec: testingv1alpha1.ClusterTestSuiteScheduleSpec{
+			Schedule:          "0 * * * *",
+			ConcurrencyPolicy: policy,
+			Template:          testingv1alpha1.TestSuiteSpec{Count: 3},
+		},
+	}
+}
+
+func givenSuite(schedule *testingv1alpha1.ClusterTestSuiteSchedule, name string, created time.Time, cond testingv1alpha1.TestSuiteConditionType) *testingv1alpha1.ClusterTestSuite {
+	isController := true
+	suite := &testingv1alpha1.ClusterTestSuite{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.Time{Time: created},
+			Labels:            map[string]string{testingv1alpha1.LabelKeyScheduleName: schedule.Name},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: testingv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "ClusterTestSuiteSchedule",
+				Name:       schedule.Name,
+				UID:        schedule.UID,
+				Controller: &isController,
+			}},
+		},
+	}
+	if cond != "" {
+		suite.Status.Conditions = []testingv1alpha1.TestSuiteCondition{{Type: cond, Status: testingv1alpha1.StatusTrue}}
+	}
+	return suite
+}
+
+func givenRequest() reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Name: "nightly"}}
+}
+
+func getSuites(t *testing.T, cli client.Client) []testingv1alpha1.ClusterTestSuite {
+	var list testingv1alpha1.ClusterTestSuiteList
+	require.NoError(t, cli.List(context.TODO(), &list))
+	return list.Items
+}
+
+func getSchedule(t *testing.T, cli client.Client) testingv1alpha1.ClusterTestSuiteSchedule {
+	var out testingv1alpha1.ClusterTestSuiteSchedule
+	require.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Name: "nightly"}, &out))
+	return out
+}
+
+func suiteNamesOf(suites []testingv1alpha1.ClusterTestSuite) []string {
+	var out []string
+	for _, s := range suites {
+		out = append(out, s.Name)
+	}
+	return out
+}
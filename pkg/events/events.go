@@ -0,0 +1,80 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons of Events recorded on suites, TestDefinitions and schedules
+const (
+	ReasonInitialized             = "Initialized"
+	ReasonInitializationFailed    = "InitializationFailed"
+	ReasonScheduled               = "Scheduled"
+	ReasonRetrying                = "Retrying"
+	ReasonExecutionSucceeded      = "ExecutionSucceeded"
+	ReasonExecutionFailed         = "ExecutionFailed"
+	ReasonExecutionTimedOut       = "ExecutionTimedOut"
+	ReasonExecutionStuckPending   = "ExecutionStuckPending"
+	ReasonExecutionLost           = "ExecutionLost"
+	ReasonSuiteTimedOut           = "SuiteTimedOut"
+	ReasonSuiteSucceeded          = "SuiteSucceeded"
+	ReasonSuiteFailed             = "SuiteFailed"
+	ReasonSuiteError              = "SuiteError"
+	ReasonSuiteAborted            = "SuiteAborted"
+	ReasonFailureThresholdReached = "FailureThresholdReached"
+	ReasonRerun                   = "Rerun"
+	ReasonInvalidRerun            = "InvalidRerun"
+	ReasonPaused                  = "Paused"
+	ReasonResumed                 = "Resumed"
+
+	ReasonSuiteCreated    = "SuiteCreated"
+	ReasonSuiteReplaced   = "SuiteReplaced"
+	ReasonInvalidSchedule = "InvalidSchedule"
+)
+
+// Recorder records Events on suites and TestDefinitions
+type Recorder struct {
+	recorder record.EventRecorder
+	reader   client.Reader
+	log      logr.Logger
+}
+
+func NewRecorder(recorder record.EventRecorder, reader client.Reader, log logr.Logger) *Recorder {
+	return &Recorder{
+		recorder: recorder,
+		reader:   reader,
+		log:      log,
+	}
+}
+
+// SuiteEvent records an Event on the suite
+func (r *Recorder) SuiteEvent(suite v1alpha1.GenericTestSuite, eventType, reason, messageFmt string, args ...interface{}) {
+	r.recorder.Eventf(suite, eventType, reason, messageFmt, args...)
+}
+
+// TestFailureEvent records a Warning on the suite and on the TestDefinition of the failed test.
+// The TestDefinition is skipped if it does not exist anymore.
+func (r *Recorder) TestFailureEvent(suite v1alpha1.GenericTestSuite, res v1alpha1.TestResult, reason, messageFmt string, args ...interface{}) {
+	r.recorder.Eventf(suite, v1.EventTypeWarning, reason, messageFmt, args...)
+
+	var def v1alpha1.TestDefinition
+	if err := r.reader.Get(context.TODO(), types.NamespacedName{Name: res.Name, Namespace: res.Namespace}, &def); err != nil {
+		r.log.Error(err, "Cannot record event on test definition", "name", res.Name, "namespace", res.Namespace)
+		return
+	}
+	r.recorder.Eventf(&def, v1.EventTypeWarning, reason, "%s [suite: %s]", fmt.Sprintf(messageFmt, args...), suiteID(suite))
+}
+
+func suiteID(suite v1alpha1.GenericTestSuite) string {
+	if v1alpha1.IsNamespaced(suite) {
+		return fmt.Sprintf("%s/%s", suite.GetNamespace(), suite.GetName())
+	}
+	return suite.GetName()
+}
@@ -0,0 +1,65 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	rlog "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestTestFailureEvent(t *testing.T) {
+	t.Run("records event on suite and test definition", func(t *testing.T) {
+		// GIVEN
+		sch := runtime.NewScheme()
+		require.NoError(t, v1alpha1.AddToScheme(sch))
+		fakeCli := fake.NewFakeClientWithScheme(sch, &v1alpha1.TestDefinition{ObjectMeta: v1.ObjectMeta{Name: "test-a", Namespace: "default"}})
+		fakeRecorder := record.NewFakeRecorder(10)
+		sut := events.NewRecorder(fakeRecorder, fakeCli, rlog.Log)
+		suite := &v1alpha1.TestSuite{ObjectMeta: v1.ObjectMeta{Name: "test-all", Namespace: "default"}}
+
+		// WHEN
+		sut.TestFailureEvent(suite, v1alpha1.TestResult{Name: "test-a", Namespace: "default"}, events.ReasonExecutionFailed, "Execution [%s] failed", "oct-np-test-all-test-a-0")
+
+		// THEN
+		require.Len(t, fakeRecorder.Events, 2)
+		assert.Equal(t, "Warning ExecutionFailed Execution [oct-np-test-all-test-a-0] failed", <-fakeRecorder.Events)
+		assert.Equal(t, "Warning ExecutionFailed Execution [oct-np-test-all-test-a-0] failed [suite: default/test-all]", <-fakeRecorder.Events)
+	})
+
+	t.Run("records event only on suite if test definition does not exist", func(t *testing.T) {
+		// GIVEN
+		sch := runtime.NewScheme()
+		require.NoError(t, v1alpha1.AddToScheme(sch))
+		fakeRecorder := record.NewFakeRecorder(10)
+		sut := events.NewRecorder(fakeRecorder, fake.NewFakeClientWithScheme(sch), rlog.Log)
+		suite := &v1alpha1.ClusterTestSuite{ObjectMeta: v1.ObjectMeta{Name: "test-all"}}
+
+		// WHEN
+		sut.TestFailureEvent(suite, v1alpha1.TestResult{Name: "test-a", Namespace: "default"}, events.ReasonExecutionTimedOut, "Execution timed out")
+
+		// THEN
+		require.Len(t, fakeRecorder.Events, 1)
+		assert.Equal(t, "Warning ExecutionTimedOut Execution timed out", <-fakeRecorder.Events)
+	})
+}
+
+func TestSuiteEvent(t *testing.T) {
+	// GIVEN
+	fakeRecorder := record.NewFakeRecorder(10)
+	sut := events.NewRecorder(fakeRecorder, nil, rlog.Log)
+
+	// WHEN
+	sut.SuiteEvent(&v1alpha1.ClusterTestSuite{}, v12.EventTypeNormal, events.ReasonInitialized, "Suite initialized with %d test(s)", 2)
+
+	// THEN
+	require.Len(t, fakeRecorder.Events, 1)
+	assert.Equal(t, "Normal Initialized Suite initialized with 2 test(s)", <-fakeRecorder.Events)
+}
@@ -23,7 +23,7 @@ type Definition struct {
 	reader client.Reader
 }
 
-func (s *Definition) FindMatching(suite v1alpha1.ClusterTestSuite) ([]v1alpha1.TestDefinition, error) {
+func (s *Definition) FindMatching(suite v1alpha1.GenericTestSuite) ([]v1alpha1.TestDefinition, error) {
 	ctx := context.TODO()
 
 	if suite.HasSelector() {
@@ -33,7 +33,42 @@ func (s *Definition) FindMatching(suite v1alpha1.ClusterTestSuite) ([]v1alpha1.T
 	return s.findAll(ctx, suite)
 }
 
-func (s *Definition) findBySelector(ctx context.Context, suite v1alpha1.ClusterTestSuite) ([]v1alpha1.TestDefinition, error) {
+// FindHooks returns the setup and teardown TestDefinitions of the suite. A hook that is not defined is returned as nil.
+func (s *Definition) FindHooks(suite v1alpha1.GenericTestSuite) (*v1alpha1.TestDefinition, *v1alpha1.TestDefinition, error) {
+	ctx := context.TODO()
+	setup, err := s.findHook(ctx, suite, "setup", suite.GetSpec().Setup)
+	if err != nil {
+		return nil, nil, err
+	}
+	teardown, err := s.findHook(ctx, suite, "teardown", suite.GetSpec().Teardown)
+	if err != nil {
+		return nil, nil, err
+	}
+	return setup, teardown, nil
+}
+
+func (s *Definition) findHook(ctx context.Context, suite v1alpha1.GenericTestSuite, hook string, ref *v1alpha1.TestDefReference) (*v1alpha1.TestDefinition, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	if v1alpha1.IsNamespaced(suite) && ref.Namespace != suite.GetNamespace() {
+		err := fmt.Errorf("test definition of %s [name: %s, namespace: %s] is not in the suite namespace [%s]", hook, ref.Name, ref.Namespace, suite.GetNamespace())
+		return nil, humanerr.NewError(err, fmt.Sprintf("Test Definition [name: %s, namespace: %s] used as %s is not in the TestSuite namespace [%s]", ref.Name, ref.Namespace, hook, suite.GetNamespace()))
+	}
+	def := v1alpha1.TestDefinition{}
+	err := s.reader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, &def)
+	wrappedErr := errors.Wrapf(err, "while fetching test definition of %s [name: %s, namespace: %s]", hook, ref.Name, ref.Namespace)
+	switch {
+	case err == nil:
+		return &def, nil
+	case k8serrors.IsNotFound(err):
+		return nil, humanerr.NewError(wrappedErr, fmt.Sprintf("Test Definition [name: %s, namespace: %s] used as %s does not exist", ref.Name, ref.Namespace, hook))
+	default:
+		return nil, humanerr.NewError(wrappedErr, "Internal error")
+	}
+}
+
+func (s *Definition) findBySelector(ctx context.Context, suite v1alpha1.GenericTestSuite) ([]v1alpha1.TestDefinition, error) {
 	byNames, err := s.findByNames(ctx, suite)
 	if err != nil {
 		return nil, err
@@ -47,9 +82,13 @@ func (s *Definition) findBySelector(ctx context.Context, suite v1alpha1.ClusterT
 	return s.unique(byNames, byLabelExpressions), nil
 }
 
-func (s *Definition) findByNames(ctx context.Context, suite v1alpha1.ClusterTestSuite) ([]v1alpha1.TestDefinition, error) {
+func (s *Definition) findByNames(ctx context.Context, suite v1alpha1.GenericTestSuite) ([]v1alpha1.TestDefinition, error) {
 	result := make([]v1alpha1.TestDefinition, 0)
-	for _, tRef := range suite.Spec.Selectors.MatchNames {
+	for _, tRef := range suite.GetSpec().Selectors.MatchNames {
+		if v1alpha1.IsNamespaced(suite) && tRef.Namespace != suite.GetNamespace() {
+			err := fmt.Errorf("test definition from selector [name: %s, namespace: %s] is not in the suite namespace [%s]", tRef.Name, tRef.Namespace, suite.GetNamespace())
+			return nil, humanerr.NewError(err, fmt.Sprintf("Test Definition [name: %s, namespace: %s] is not in the TestSuite namespace [%s]", tRef.Name, tRef.Namespace, suite.GetNamespace()))
+		}
 		def := v1alpha1.TestDefinition{}
 		err := s.reader.Get(ctx, types.NamespacedName{Name: tRef.Name, Namespace: tRef.Namespace}, &def)
 		wrappedErr := errors.Wrapf(err, "while fetching test definition from selector [name: %s, namespace: %s]", tRef.Name, tRef.Namespace)
@@ -65,15 +104,15 @@ func (s *Definition) findByNames(ctx context.Context, suite v1alpha1.ClusterTest
 	return result, nil
 }
 
-func (s *Definition) findByLabelExpressions(ctx context.Context, suite v1alpha1.ClusterTestSuite) ([]v1alpha1.TestDefinition, error) {
+func (s *Definition) findByLabelExpressions(ctx context.Context, suite v1alpha1.GenericTestSuite) ([]v1alpha1.TestDefinition, error) {
 	result := make([]v1alpha1.TestDefinition, 0)
-	for _, expr := range suite.Spec.Selectors.MatchLabelExpressions {
+	for _, expr := range suite.GetSpec().Selectors.MatchLabelExpressions {
 		selector, err := labels.Parse(expr)
 		if err != nil {
 			return nil, errors.Wrapf(err, "while parsing label expression [expression: %s]", expr)
 		}
 		var list v1alpha1.TestDefinitionList
-		if err := s.reader.List(ctx, &list, &client.ListOptions{LabelSelector: selector}); err != nil {
+		if err := s.reader.List(ctx, &list, &client.ListOptions{Namespace: suite.GetNamespace(), LabelSelector: selector}); err != nil {
 			return nil, errors.Wrapf(err, "while fetching test definition from selector [expression: %s]", expr)
 		}
 		result = append(result, list.Items...)
@@ -97,9 +136,10 @@ func (s *Definition) unique(slices ...[]v1alpha1.TestDefinition) []v1alpha1.Test
 	return result
 }
 
-func (s *Definition) findAll(ctx context.Context, suite v1alpha1.ClusterTestSuite) ([]v1alpha1.TestDefinition, error) {
+func (s *Definition) findAll(ctx context.Context, suite v1alpha1.GenericTestSuite) ([]v1alpha1.TestDefinition, error) {
 	var list v1alpha1.TestDefinitionList
-	if err := s.reader.List(ctx, &list, &client.ListOptions{Namespace: ""}); err != nil {
+	// namespace of a ClusterTestSuite is empty, so test definitions from all namespaces are listed
+	if err := s.reader.List(ctx, &list, &client.ListOptions{Namespace: suite.GetNamespace()}); err != nil {
 		return nil, errors.Wrap(err, "while listing test definitions")
 	}
 	return list.Items, nil
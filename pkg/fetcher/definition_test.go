@@ -33,7 +33,7 @@ func TestFindMatching(t *testing.T) {
 		service := fetcher.NewForDefinition(fakeCli)
 
 		// WHEN
-		out, err := service.FindMatching(v1alpha1.ClusterTestSuite{})
+		out, err := service.FindMatching(&v1alpha1.ClusterTestSuite{})
 		// THEN
 		require.NoError(t, err)
 		assert.Len(t, out, 1)
@@ -80,7 +80,7 @@ func TestFindMatching(t *testing.T) {
 		)
 		service := fetcher.NewForDefinition(fakeCli)
 		// WHEN
-		out, err := service.FindMatching(v1alpha1.ClusterTestSuite{
+		out, err := service.FindMatching(&v1alpha1.ClusterTestSuite{
 			Spec: v1alpha1.TestSuiteSpec{
 				Selectors: v1alpha1.TestsSelector{
 					MatchNames: []v1alpha1.TestDefReference{
@@ -142,7 +142,7 @@ func TestFindMatching(t *testing.T) {
 		)
 		service := fetcher.NewForDefinition(fakeCli)
 		// WHEN
-		out, err := service.FindMatching(v1alpha1.ClusterTestSuite{
+		out, err := service.FindMatching(&v1alpha1.ClusterTestSuite{
 			Spec: v1alpha1.TestSuiteSpec{
 				Selectors: v1alpha1.TestsSelector{
 					MatchLabelExpressions: []string{
@@ -177,7 +177,7 @@ func TestFindMatching(t *testing.T) {
 		)
 		service := fetcher.NewForDefinition(fakeCli)
 		// WHEN
-		out, err := service.FindMatching(v1alpha1.ClusterTestSuite{
+		out, err := service.FindMatching(&v1alpha1.ClusterTestSuite{
 			Spec: v1alpha1.TestSuiteSpec{
 				Selectors: v1alpha1.TestsSelector{
 					MatchNames: []v1alpha1.TestDefReference{
@@ -203,7 +203,7 @@ func TestFindMatching(t *testing.T) {
 		fakeCli := fake.NewFakeClientWithScheme(sch)
 		service := fetcher.NewForDefinition(fakeCli)
 		// WHEN
-		_, err := service.FindMatching(v1alpha1.ClusterTestSuite{
+		_, err := service.FindMatching(&v1alpha1.ClusterTestSuite{
 			Spec: v1alpha1.TestSuiteSpec{
 				Selectors: v1alpha1.TestsSelector{
 					MatchNames: []v1alpha1.TestDefReference{
@@ -228,7 +228,7 @@ func TestFindMatching(t *testing.T) {
 		service := fetcher.NewForDefinition(errClient)
 
 		// WHEN
-		_, err := service.FindMatching(v1alpha1.ClusterTestSuite{
+		_, err := service.FindMatching(&v1alpha1.ClusterTestSuite{
 			Spec: v1alpha1.TestSuiteSpec{
 				Selectors: v1alpha1.TestsSelector{
 					MatchNames: []v1alpha1.TestDefReference{
@@ -248,6 +248,149 @@ func TestFindMatching(t *testing.T) {
 
 	})
 
+	t.Run("return only tests from the namespace of a namespaced suite", func(t *testing.T) {
+		// GIVEN
+		testA := &v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{
+				UID:       "test-uid-a",
+				Name:      "test-a",
+				Namespace: "team-a",
+				Labels: map[string]string{
+					"test": "true",
+				},
+			},
+		}
+		testB := &v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{
+				UID:       "test-uid-b",
+				Name:      "test-b",
+				Namespace: "team-b",
+				Labels: map[string]string{
+					"test": "true",
+				},
+			},
+		}
+		fakeCli := fake.NewFakeClientWithScheme(sch, testA, testB)
+		service := fetcher.NewForDefinition(fakeCli)
+		suiteMeta := v1.ObjectMeta{Name: "suite", Namespace: "team-a"}
+
+		// WHEN
+		all, err := service.FindMatching(&v1alpha1.TestSuite{ObjectMeta: suiteMeta})
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha1.TestDefinition{*testA}, all)
+
+		// WHEN
+		byLabels, err := service.FindMatching(&v1alpha1.TestSuite{
+			ObjectMeta: suiteMeta,
+			Spec: v1alpha1.TestSuiteSpec{
+				Selectors: v1alpha1.TestsSelector{
+					MatchLabelExpressions: []string{"test=true"},
+				},
+			},
+		})
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha1.TestDefinition{*testA}, byLabels)
+	})
+
+	t.Run("return error if namespaced suite selects test by name from other namespace", func(t *testing.T) {
+		// GIVEN
+		fakeCli := fake.NewFakeClientWithScheme(sch)
+		service := fetcher.NewForDefinition(fakeCli)
+		// WHEN
+		_, err := service.FindMatching(&v1alpha1.TestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "suite", Namespace: "team-a"},
+			Spec: v1alpha1.TestSuiteSpec{
+				Selectors: v1alpha1.TestsSelector{
+					MatchNames: []v1alpha1.TestDefReference{
+						{
+							Name:      "name",
+							Namespace: "team-b",
+						},
+					},
+				},
+			},
+		})
+		// THEN
+		require.EqualError(t, err, "test definition from selector [name: name, namespace: team-b] is not in the suite namespace [team-a]")
+		herr, ok := humanerr.GetHumanReadableError(err)
+		require.True(t, ok)
+		assert.Equal(t, "Test Definition [name: name, namespace: team-b] is not in the TestSuite namespace [team-a]", herr.Message)
+	})
+
+}
+
+func TestFindHooks(t *testing.T) {
+	sch, err := v1alpha1.SchemeBuilder.Build()
+	require.NoError(t, err)
+
+	t.Run("return nil if hooks are not defined", func(t *testing.T) {
+		// GIVEN
+		fakeCli := fake.NewFakeClientWithScheme(sch)
+		service := fetcher.NewForDefinition(fakeCli)
+		// WHEN
+		setup, teardown, err := service.FindHooks(&v1alpha1.ClusterTestSuite{})
+		// THEN
+		require.NoError(t, err)
+		assert.Nil(t, setup)
+		assert.Nil(t, teardown)
+	})
+
+	t.Run("return setup and teardown", func(t *testing.T) {
+		// GIVEN
+		seed := &v1alpha1.TestDefinition{ObjectMeta: v1.ObjectMeta{Name: "seed", Namespace: "default"}}
+		cleanup := &v1alpha1.TestDefinition{ObjectMeta: v1.ObjectMeta{Name: "cleanup", Namespace: "default"}}
+		fakeCli := fake.NewFakeClientWithScheme(sch, seed, cleanup)
+		service := fetcher.NewForDefinition(fakeCli)
+		// WHEN
+		setup, teardown, err := service.FindHooks(&v1alpha1.ClusterTestSuite{
+			Spec: v1alpha1.TestSuiteSpec{
+				Setup:    &v1alpha1.TestDefReference{Name: "seed", Namespace: "default"},
+				Teardown: &v1alpha1.TestDefReference{Name: "cleanup", Namespace: "default"},
+			},
+		})
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, setup)
+		assert.Equal(t, "seed", setup.Name)
+		require.NotNil(t, teardown)
+		assert.Equal(t, "cleanup", teardown.Name)
+	})
+
+	t.Run("return error if hook does not exist", func(t *testing.T) {
+		// GIVEN
+		fakeCli := fake.NewFakeClientWithScheme(sch)
+		service := fetcher.NewForDefinition(fakeCli)
+		// WHEN
+		_, _, err := service.FindHooks(&v1alpha1.ClusterTestSuite{
+			Spec: v1alpha1.TestSuiteSpec{
+				Teardown: &v1alpha1.TestDefReference{Name: "cleanup", Namespace: "default"},
+			},
+		})
+		// THEN
+		require.EqualError(t, err, "while fetching test definition of teardown [name: cleanup, namespace: default]: testdefinitions.testing.kyma-project.io \"cleanup\" not found")
+		herr, ok := humanerr.GetHumanReadableError(err)
+		require.True(t, ok)
+		assert.Equal(t, "Test Definition [name: cleanup, namespace: default] used as teardown does not exist", herr.Message)
+	})
+
+	t.Run("return error if namespaced suite uses hook from other namespace", func(t *testing.T) {
+		// GIVEN
+		fakeCli := fake.NewFakeClientWithScheme(sch)
+		service := fetcher.NewForDefinition(fakeCli)
+		// WHEN
+		_, _, err := service.FindHooks(&v1alpha1.TestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "suite", Namespace: "team-a"},
+			Spec: v1alpha1.TestSuiteSpec{
+				Setup: &v1alpha1.TestDefReference{Name: "seed", Namespace: "team-b"},
+			},
+		})
+		// THEN
+		herr, ok := humanerr.GetHumanReadableError(err)
+		require.True(t, ok)
+		assert.Equal(t, "Test Definition [name: seed, namespace: team-b] used as setup is not in the TestSuite namespace [team-a]", herr.Message)
+	})
 }
 
 type mockErrReader struct {
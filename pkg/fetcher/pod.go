@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
 	"github.com/pkg/errors"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,24 +22,33 @@ type TestPod struct {
 	cli client.Reader
 }
 
-func (s *TestPod) GetPodsForSuite(ctx context.Context, suite v1alpha1.ClusterTestSuite) ([]v1.Pod, error) {
+func (s *TestPod) GetPodsForSuite(ctx context.Context, suite v1alpha1.GenericTestSuite) ([]v1.Pod, error) {
 	var out v1.PodList
 	reqCreatedBy, err := labels.NewRequirement(v1alpha1.LabelKeyCreatedByOctopus, selection.Equals, []string{"true"})
 	if err != nil {
 		return nil, errors.Wrapf(err, "while creating '%s' label requirement", v1alpha1.LabelKeyCreatedByOctopus)
 	}
-	reqSuiteName, err := labels.NewRequirement(v1alpha1.LabelKeySuiteName, selection.Equals, []string{suite.Name})
+	reqSuiteName, err := labels.NewRequirement(v1alpha1.LabelKeySuiteName, selection.Equals, []string{suite.GetName()})
 	if err != nil {
 		return nil, errors.Wrapf(err, "while creating '%s' label requirement", v1alpha1.LabelKeySuiteName)
 	}
 
+	// namespace of a ClusterTestSuite is empty, so pods from all namespaces are listed
 	if err := s.cli.List(ctx, &out, &client.ListOptions{
-		Namespace:     v1.NamespaceAll,
+		Namespace:     suite.GetNamespace(),
 		LabelSelector: labels.NewSelector().Add(*reqCreatedBy, *reqSuiteName),
 	}); err != nil {
-		return nil, errors.Wrapf(err, "while getting pods for suite [%s]", suite.Name)
+		return nil, errors.Wrapf(err, "while getting pods for suite [%s]", suite.GetName())
 	}
 
 	// TODO(aszecowka)(later) deal with pagination
-	return out.Items, nil
+
+	// ClusterTestSuite and TestSuite can have the same name, so pods are filtered by their owner
+	result := make([]v1.Pod, 0, len(out.Items))
+	for _, pod := range out.Items {
+		if metav1.IsControlledBy(&pod, suite) {
+			result = append(result, pod)
+		}
+	}
+	return result, nil
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -29,11 +30,13 @@ func TestGetPodsForSuite(t *testing.T) {
 				v1alpha1.LabelKeyCreatedByOctopus: "true",
 				v1alpha1.LabelKeySuiteName:        "test-all-suite",
 			},
+			OwnerReferences: []v12.OwnerReference{givenOwnerReference("suite-uid")},
 		},
 	}
 
 	givenSuite := v1alpha1.ClusterTestSuite{ObjectMeta: v12.ObjectMeta{
 		Name: "test-all-suite",
+		UID:  "suite-uid",
 	}}
 
 	mockReader := &automock.Reader{}
@@ -47,14 +50,14 @@ func TestGetPodsForSuite(t *testing.T) {
 
 	sut := fetcher.NewForTestingPod(cli)
 	// WHEN
-	actualPods, err := sut.GetPodsForSuite(context.TODO(), givenSuite)
+	actualPods, err := sut.GetPodsForSuite(context.TODO(), &givenSuite)
 	// THEN
 	require.NoError(t, err)
 	require.Len(t, actualPods, 1)
 	assert.Equal(t, givenPod, actualPods[0])
 
 	// WHEN
-	actualPods, err = sut.GetPodsForSuite(context.Background(), v1alpha1.ClusterTestSuite{ObjectMeta: v12.ObjectMeta{
+	actualPods, err = sut.GetPodsForSuite(context.Background(), &v1alpha1.ClusterTestSuite{ObjectMeta: v12.ObjectMeta{
 		Name: "wrong-name",
 	}})
 	// THEN
@@ -62,6 +65,56 @@ func TestGetPodsForSuite(t *testing.T) {
 	require.Len(t, actualPods, 0)
 }
 
+func TestGetPodsForNamespacedSuite(t *testing.T) {
+	// GIVEN
+	givenPod := givenTestingPod("oct-np-test-all-suite-test-a-0", "aaa", "ns-suite-uid")
+	clusterSuitePod := givenTestingPod("oct-tp-test-all-suite-test-a-0", "aaa", "cluster-suite-uid")
+	otherNsPod := givenTestingPod("oct-np-test-all-suite-test-b-0", "bbb", "other-ns-suite-uid")
+
+	givenSuite := v1alpha1.TestSuite{ObjectMeta: v12.ObjectMeta{
+		Name:      "test-all-suite",
+		Namespace: "aaa",
+		UID:       "ns-suite-uid",
+	}}
+
+	sch, err := v1alpha1.SchemeBuilder.Build()
+	require.NoError(t, err)
+	require.NoError(t, v1.AddToScheme(sch))
+
+	cli := fake.NewFakeClientWithScheme(sch, &givenPod, &clusterSuitePod, &otherNsPod)
+
+	sut := fetcher.NewForTestingPod(cli)
+	// WHEN
+	actualPods, err := sut.GetPodsForSuite(context.TODO(), &givenSuite)
+	// THEN
+	require.NoError(t, err)
+	require.Len(t, actualPods, 1)
+	assert.Equal(t, givenPod, actualPods[0])
+}
+
+func givenTestingPod(name, ns, ownerUID string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: v12.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels: map[string]string{
+				v1alpha1.LabelKeyCreatedByOctopus: "true",
+				v1alpha1.LabelKeySuiteName:        "test-all-suite",
+			},
+			OwnerReferences: []v12.OwnerReference{givenOwnerReference(ownerUID)},
+		},
+	}
+}
+
+func givenOwnerReference(uid string) v12.OwnerReference {
+	isController := true
+	return v12.OwnerReference{
+		Name:       "test-all-suite",
+		UID:        types.UID(uid),
+		Controller: &isController,
+	}
+}
+
 func TestGetPodsForSuiteOnError(t *testing.T) {
 	givenSuite := v1alpha1.ClusterTestSuite{ObjectMeta: v12.ObjectMeta{
 		Name: "test-all-suite",
@@ -72,7 +125,7 @@ func TestGetPodsForSuiteOnError(t *testing.T) {
 	defer mockReader.AssertExpectations(t)
 	sut := fetcher.NewForTestingPod(mockReader)
 	// WHEN
-	_, err := sut.GetPodsForSuite(context.TODO(), givenSuite)
+	_, err := sut.GetPodsForSuite(context.TODO(), &givenSuite)
 	// THEN
 	require.EqualError(t, err, "while getting pods for suite [test-all-suite]: some error")
 }
@@ -0,0 +1,115 @@
+package flakiness
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Service keeps results of tests from the most recent suites in statuses of TestDefinitions,
+// so that flaky tests can be found across suites
+type Service struct {
+	client client.Client
+	limit  int
+	log    logr.Logger
+}
+
+func NewService(cli client.Client, limit int, logger logr.Logger) *Service {
+	return &Service{
+		client: cli,
+		limit:  limit,
+		log:    logger,
+	}
+}
+
+// RecordSuite adds results of a finished suite to the history of all TestDefinitions executed by the suite.
+// A result of a suite that is already in the history, e.g. because the suite was rerun, is replaced.
+// Every variant of a test from the TestDefinition matrix has a separate entry.
+func (s *Service) RecordSuite(ctx context.Context, suite v1alpha1.GenericTestSuite) error {
+	if s.limit <= 0 {
+		return nil
+	}
+	var errs error
+	for _, res := range suite.GetStatus().Results {
+		if len(res.Executions) == 0 {
+			continue
+		}
+		if err := s.recordResult(ctx, res, newSummary(suite, res)); err != nil {
+			errs = multierr.Append(errs, errors.Wrapf(err, "while recording result of suite [%s] in history of test definition [name: %s, namespace: %s]", suite.GetName(), res.Name, res.Namespace))
+		}
+	}
+	return errs
+}
+
+func (s *Service) recordResult(ctx context.Context, res v1alpha1.TestResult, summary v1alpha1.TestRunSummary) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var def v1alpha1.TestDefinition
+		if err := s.client.Get(ctx, types.NamespacedName{Name: res.Name, Namespace: res.Namespace}, &def); err != nil {
+			if k8serrors.IsNotFound(err) {
+				s.log.Info("Test definition does not exist anymore, history is not recorded", "name", res.Name, "namespace", res.Namespace)
+				return nil
+			}
+			return err
+		}
+		def.Status = addToHistory(def.Status, summary, s.limit)
+		return s.client.Status().Update(ctx, &def)
+	})
+}
+
+// addToHistory puts the summary at the beginning of the history, removes the oldest entries over the limit
+// and recalculates counters
+func addToHistory(stat v1alpha1.TestDefinitionStatus, summary v1alpha1.TestRunSummary, limit int) v1alpha1.TestDefinitionStatus {
+	out := v1alpha1.TestDefinitionStatus{
+		History: []v1alpha1.TestRunSummary{summary},
+	}
+	for _, prev := range stat.History {
+		if len(out.History) >= limit {
+			break
+		}
+		if prev.Suite == summary.Suite && prev.Variant == summary.Variant {
+			continue
+		}
+		out.History = append(out.History, prev)
+	}
+	for _, h := range out.History {
+		switch h.Status {
+		case v1alpha1.TestFlaky:
+			out.FlakyRuns++
+		case v1alpha1.TestFailed:
+			out.FailedRuns++
+		}
+	}
+	return out
+}
+
+func newSummary(suite v1alpha1.GenericTestSuite, res v1alpha1.TestResult) v1alpha1.TestRunSummary {
+	out := v1alpha1.TestRunSummary{
+		Suite:          suiteID(suite),
+		Variant:        res.Variant,
+		Status:         res.Status,
+		Executions:     int64(len(res.Executions)),
+		CompletionTime: suite.GetStatus().CompletionTime,
+	}
+	for _, exec := range res.Executions {
+		if exec.PodPhase == v1.PodFailed {
+			out.FailedExecutions++
+		}
+	}
+	return out
+}
+
+func suiteID(suite v1alpha1.GenericTestSuite) string {
+	if v1alpha1.IsNamespaced(suite) {
+		return fmt.Sprintf("%s/%s", suite.GetNamespace(), suite.GetName())
+	}
+	return suite.GetName()
+}
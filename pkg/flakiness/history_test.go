@@ -0,0 +1,164 @@
+package flakiness_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/flakiness"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	rlog "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestRecordSuite(t *testing.T) {
+	t.Run("adds results of executed tests to history", func(t *testing.T) {
+		// GIVEN
+		cli := givenClient(t, givenDefinition("test-a"), givenDefinition("test-b"), givenDefinition("test-c"))
+		sut := flakiness.NewService(cli, 3, rlog.Log)
+		suite := givenSuite("suite-1",
+			v1alpha1.TestResult{Name: "test-a", Namespace: "default", Status: v1alpha1.TestFlaky, Executions: []v1alpha1.TestExecution{
+				{ID: "oct-tp-suite-1-test-a-0", PodPhase: v12.PodFailed},
+				{ID: "oct-tp-suite-1-test-a-1", PodPhase: v12.PodSucceeded},
+			}},
+			v1alpha1.TestResult{Name: "test-b", Namespace: "default", Status: v1alpha1.TestFailed, Executions: []v1alpha1.TestExecution{
+				{ID: "oct-tp-suite-1-test-b-0", PodPhase: v12.PodFailed},
+			}},
+			v1alpha1.TestResult{Name: "test-c", Namespace: "default", Status: v1alpha1.TestSkipped},
+		)
+
+		// WHEN
+		err := sut.RecordSuite(context.TODO(), suite)
+
+		// THEN
+		require.NoError(t, err)
+		defA := getDefinition(t, cli, "test-a")
+		assert.Equal(t, []v1alpha1.TestRunSummary{
+			{Suite: "suite-1", Status: v1alpha1.TestFlaky, Executions: 2, FailedExecutions: 1},
+		}, defA.Status.History)
+		assert.Equal(t, int64(1), defA.Status.FlakyRuns)
+		assert.Equal(t, int64(0), defA.Status.FailedRuns)
+		defB := getDefinition(t, cli, "test-b")
+		assert.Equal(t, int64(1), defB.Status.FailedRuns)
+		assert.Empty(t, getDefinition(t, cli, "test-c").Status.History)
+	})
+
+	t.Run("keeps limited number of the most recent suites", func(t *testing.T) {
+		// GIVEN
+		cli := givenClient(t, givenDefinition("test-a"))
+		sut := flakiness.NewService(cli, 2, rlog.Log)
+		statuses := []v1alpha1.TestStatus{v1alpha1.TestFlaky, v1alpha1.TestFailed, v1alpha1.TestSucceeded}
+
+		// WHEN
+		for idx, st := range statuses {
+			suite := givenSuite(fmt.Sprintf("suite-%d", idx), v1alpha1.TestResult{Name: "test-a", Namespace: "default", Status: st, Executions: []v1alpha1.TestExecution{
+				{ID: "oct-tp-test-a-0", PodPhase: v12.PodSucceeded},
+			}})
+			require.NoError(t, sut.RecordSuite(context.TODO(), suite))
+		}
+
+		// THEN
+		def := getDefinition(t, cli, "test-a")
+		require.Len(t, def.Status.History, 2)
+		assert.Equal(t, "suite-2", def.Status.History[0].Suite)
+		assert.Equal(t, "suite-1", def.Status.History[1].Suite)
+		assert.Equal(t, int64(0), def.Status.FlakyRuns)
+		assert.Equal(t, int64(1), def.Status.FailedRuns)
+	})
+
+	t.Run("replaces result of rerun suite", func(t *testing.T) {
+		// GIVEN
+		cli := givenClient(t, givenDefinition("test-a"))
+		sut := flakiness.NewService(cli, 3, rlog.Log)
+		failed := givenSuite("suite-1", v1alpha1.TestResult{Name: "test-a", Namespace: "default", Status: v1alpha1.TestFailed, Executions: []v1alpha1.TestExecution{
+			{ID: "oct-tp-suite-1-test-a-0", PodPhase: v12.PodFailed},
+		}})
+		require.NoError(t, sut.RecordSuite(context.TODO(), failed))
+		rerun := givenSuite("suite-1", v1alpha1.TestResult{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSucceeded, Executions: []v1alpha1.TestExecution{
+			{ID: "oct-tp-suite-1-test-a-1", PodPhase: v12.PodSucceeded},
+		}})
+
+		// WHEN
+		err := sut.RecordSuite(context.TODO(), rerun)
+
+		// THEN
+		require.NoError(t, err)
+		def := getDefinition(t, cli, "test-a")
+		require.Len(t, def.Status.History, 1)
+		assert.Equal(t, v1alpha1.TestSucceeded, def.Status.History[0].Status)
+		assert.Equal(t, int64(0), def.Status.FailedRuns)
+	})
+
+	t.Run("keeps separate results of variants", func(t *testing.T) {
+		// GIVEN
+		cli := givenClient(t, givenDefinition("test-a"))
+		sut := flakiness.NewService(cli, 3, rlog.Log)
+		suite := givenSuite("suite-1",
+			v1alpha1.TestResult{Name: "test-a", Namespace: "default", Variant: "postgres", Status: v1alpha1.TestFailed, Executions: []v1alpha1.TestExecution{
+				{ID: "oct-tp-suite-1-test-a-postgres-0", PodPhase: v12.PodFailed},
+			}},
+			v1alpha1.TestResult{Name: "test-a", Namespace: "default", Variant: "mysql", Status: v1alpha1.TestSucceeded, Executions: []v1alpha1.TestExecution{
+				{ID: "oct-tp-suite-1-test-a-mysql-0", PodPhase: v12.PodSucceeded},
+			}},
+		)
+
+		// WHEN
+		err := sut.RecordSuite(context.TODO(), suite)
+
+		// THEN
+		require.NoError(t, err)
+		def := getDefinition(t, cli, "test-a")
+		assert.Equal(t, []v1alpha1.TestRunSummary{
+			{Suite: "suite-1", Variant: "mysql", Status: v1alpha1.TestSucceeded, Executions: 1},
+			{Suite: "suite-1", Variant: "postgres", Status: v1alpha1.TestFailed, Executions: 1, FailedExecutions: 1},
+		}, def.Status.History)
+		assert.Equal(t, int64(1), def.Status.FailedRuns)
+	})
+
+	t.Run("ignores removed test definitions", func(t *testing.T) {
+		// GIVEN
+		cli := givenClient(t)
+		sut := flakiness.NewService(cli, 3, rlog.Log)
+		suite := givenSuite("suite-1", v1alpha1.TestResult{Name: "test-a", Namespace: "default", Status: v1alpha1.TestFailed, Executions: []v1alpha1.TestExecution{
+			{ID: "oct-tp-suite-1-test-a-0", PodPhase: v12.PodFailed},
+		}})
+
+		// WHEN
+		err := sut.RecordSuite(context.TODO(), suite)
+
+		// THEN
+		require.NoError(t, err)
+	})
+}
+
+func givenClient(t *testing.T, objects ...runtime.Object) client.Client {
+	sch := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(sch))
+	return fake.NewFakeClientWithScheme(sch, objects...)
+}
+
+func givenDefinition(name string) *v1alpha1.TestDefinition {
+	return &v1alpha1.TestDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default"},
+	}
+}
+
+func givenSuite(name string, results ...v1alpha1.TestResult) *v1alpha1.ClusterTestSuite {
+	return &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: name},
+		Status:     v1alpha1.TestSuiteStatus{Results: results},
+	}
+}
+
+func getDefinition(t *testing.T, cli client.Client, name string) v1alpha1.TestDefinition {
+	var out v1alpha1.TestDefinition
+	require.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, &out))
+	return out
+}
@@ -0,0 +1,125 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PodLogsGetter returns logs of a single container of a pod
+type PodLogsGetter interface {
+	GetLogs(ctx context.Context, pod v1.Pod, opts v1.PodLogOptions) ([]byte, error)
+}
+
+// Collector gets logs of testing pods whose executions are finished and stores them in the Sink.
+type Collector struct {
+	getter     PodLogsGetter
+	sink       Sink
+	tailLines  int64
+	limitBytes int64
+	log        logr.Logger
+}
+
+// NewCollector returns a Collector. Logs are not collected if sink is nil.
+func NewCollector(getter PodLogsGetter, sink Sink, tailLines, limitBytes int64, logger logr.Logger) *Collector {
+	return &Collector{
+		getter:     getter,
+		sink:       sink,
+		tailLines:  tailLines,
+		limitBytes: limitBytes,
+		log:        logger,
+	}
+}
+
+// CollectLogs stores logs of testing pods whose executions are finished and returns the status with references to them.
+// Collecting logs must not block the suite, so failures are only logged and collecting is retried on next call.
+func (c *Collector) CollectLogs(ctx context.Context, suite v1alpha1.GenericTestSuite, pods []v1.Pod) *v1alpha1.TestSuiteStatus {
+	out := suite.GetStatus().DeepCopy()
+	if c.sink == nil {
+		return out
+	}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		exec := c.findExecution(out, pod)
+		if exec == nil || exec.LogsRef != "" || !c.isFinished(*exec) {
+			continue
+		}
+
+		ref, err := c.sink.Store(ctx, suite, pod, c.getLogs(ctx, pod))
+		if err != nil {
+			c.log.Error(err, "Cannot store logs of testing pod", "suite", suite.GetName(), "podName", pod.Name, "podNs", pod.Namespace)
+			continue
+		}
+		exec.LogsRef = ref
+	}
+	return out
+}
+
+// getLogs returns logs of all containers. If logs of a container are not available, e.g. because it was not started,
+// the error is stored instead.
+func (c *Collector) getLogs(ctx context.Context, pod v1.Pod) []ContainerLogs {
+	var containers []v1.Container
+	containers = append(containers, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+
+	out := make([]ContainerLogs, 0, len(containers))
+	for _, container := range containers {
+		opts := v1.PodLogOptions{Container: container.Name}
+		if c.tailLines > 0 {
+			opts.TailLines = &c.tailLines
+		}
+		if c.limitBytes > 0 {
+			opts.LimitBytes = &c.limitBytes
+		}
+		logs, err := c.getter.GetLogs(ctx, pod, opts)
+		if err != nil {
+			logs = []byte(fmt.Sprintf("cannot get logs of container: %s", err))
+		}
+		out = append(out, ContainerLogs{Container: container.Name, Logs: logs})
+	}
+	return out
+}
+
+// findExecution returns the execution of the testing pod, including executions of the setup and teardown
+func (c *Collector) findExecution(stat *v1alpha1.TestSuiteStatus, pod v1.Pod) *v1alpha1.TestExecution {
+	for _, tr := range stat.GetAllResults() {
+		if tr.Name != pod.Labels[v1alpha1.LabelKeyTestDefName] || tr.Namespace != pod.Namespace {
+			continue
+		}
+		for execIdx, exec := range tr.Executions {
+			if exec.ID == pod.Name {
+				return &tr.Executions[execIdx]
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Collector) isFinished(exec v1alpha1.TestExecution) bool {
+	return exec.PodPhase == v1.PodSucceeded || exec.PodPhase == v1.PodFailed
+}
+
+// ClientsetLogsGetter gets logs using the Kubernetes clientset, because the controller-runtime client does not support
+// the log subresource.
+type ClientsetLogsGetter struct {
+	clientset kubernetes.Interface
+}
+
+func NewClientsetLogsGetter(clientset kubernetes.Interface) *ClientsetLogsGetter {
+	return &ClientsetLogsGetter{clientset: clientset}
+}
+
+func (g *ClientsetLogsGetter) GetLogs(ctx context.Context, pod v1.Pod, opts v1.PodLogOptions) ([]byte, error) {
+	logs, err := g.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &opts).DoRaw(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while getting logs of container [%s] of pod [name: %s, namespace: %s]", opts.Container, pod.Name, pod.Namespace)
+	}
+	return logs, nil
+}
@@ -0,0 +1,157 @@
+package logs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	rlog "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestCollectLogs(t *testing.T) {
+	// GIVEN
+	finishedPod := givenPod("oct-tp-test-all-test-a-0", "test-a", "main")
+	runningPod := givenPod("oct-tp-test-all-test-b-0", "test-b", "main")
+	collectedPod := givenPod("oct-tp-test-all-test-c-0", "test-c", "main")
+	notStartedPod := givenPod("oct-tp-test-all-test-d-0", "test-d", "main")
+
+	suite := &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-all",
+		},
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				givenResult("test-a", v1alpha1.TestExecution{ID: finishedPod.Name, PodPhase: v12.PodFailed}),
+				givenResult("test-b", v1alpha1.TestExecution{ID: runningPod.Name, PodPhase: v12.PodRunning}),
+				givenResult("test-c", v1alpha1.TestExecution{ID: collectedPod.Name, PodPhase: v12.PodSucceeded, LogsRef: "configmap://default/previous"}),
+				givenResult("test-d", v1alpha1.TestExecution{ID: notStartedPod.Name, PodPhase: v12.PodFailed}),
+			},
+		},
+	}
+
+	getter := &fakeLogsGetter{
+		logs: map[string]string{finishedPod.Name: "test failed"},
+		errs: map[string]error{notStartedPod.Name: errors.New("container is waiting to start")},
+	}
+	sink := &fakeSink{}
+	sut := logs.NewCollector(getter, sink, 100, 0, rlog.Log)
+
+	// WHEN
+	out := sut.CollectLogs(context.TODO(), suite, []v12.Pod{finishedPod, runningPod, collectedPod, notStartedPod})
+
+	// THEN
+	assert.Equal(t, "fake://oct-tp-test-all-test-a-0", out.Results[0].Executions[0].LogsRef)
+	assert.Empty(t, out.Results[1].Executions[0].LogsRef)
+	assert.Equal(t, "configmap://default/previous", out.Results[2].Executions[0].LogsRef)
+	assert.Equal(t, "fake://oct-tp-test-all-test-d-0", out.Results[3].Executions[0].LogsRef)
+	assert.Empty(t, suite.Status.Results[0].Executions[0].LogsRef, "suite should not be modified")
+
+	require.Len(t, sink.stored, 2)
+	assert.Equal(t, []logs.ContainerLogs{{Container: "main", Logs: []byte("test failed")}}, sink.stored[finishedPod.Name])
+	assert.Equal(t, []logs.ContainerLogs{{Container: "main", Logs: []byte("cannot get logs of container: container is waiting to start")}}, sink.stored[notStartedPod.Name])
+	require.NotNil(t, getter.lastOpts.TailLines)
+	assert.Equal(t, int64(100), *getter.lastOpts.TailLines)
+	assert.Nil(t, getter.lastOpts.LimitBytes)
+}
+
+func TestCollectLogsRetriesOnSinkFailure(t *testing.T) {
+	// GIVEN
+	finishedPod := givenPod("oct-tp-test-all-test-a-0", "test-a", "main")
+	suite := &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-all",
+		},
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				givenResult("test-a", v1alpha1.TestExecution{ID: finishedPod.Name, PodPhase: v12.PodSucceeded}),
+			},
+		},
+	}
+	sut := logs.NewCollector(&fakeLogsGetter{}, &fakeSink{err: errors.New("some error")}, 0, 0, rlog.Log)
+
+	// WHEN
+	out := sut.CollectLogs(context.TODO(), suite, []v12.Pod{finishedPod})
+
+	// THEN
+	assert.Empty(t, out.Results[0].Executions[0].LogsRef)
+}
+
+func TestCollectLogsDisabled(t *testing.T) {
+	// GIVEN
+	finishedPod := givenPod("oct-tp-test-all-test-a-0", "test-a", "main")
+	suite := &v1alpha1.ClusterTestSuite{
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				givenResult("test-a", v1alpha1.TestExecution{ID: finishedPod.Name, PodPhase: v12.PodSucceeded}),
+			},
+		},
+	}
+	sut := logs.NewCollector(&fakeLogsGetter{}, nil, 0, 0, rlog.Log)
+
+	// WHEN
+	out := sut.CollectLogs(context.TODO(), suite, []v12.Pod{finishedPod})
+
+	// THEN
+	assert.Equal(t, suite.Status, *out)
+}
+
+func givenPod(name, testDef string, containers ...string) v12.Pod {
+	pod := v12.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				v1alpha1.LabelKeyTestDefName: testDef,
+				v1alpha1.LabelKeySuiteName:   "test-all",
+			},
+		},
+	}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, v12.Container{Name: c})
+	}
+	return pod
+}
+
+func givenResult(testDef string, exec v1alpha1.TestExecution) v1alpha1.TestResult {
+	return v1alpha1.TestResult{
+		Name:       testDef,
+		Namespace:  "default",
+		Executions: []v1alpha1.TestExecution{exec},
+	}
+}
+
+type fakeLogsGetter struct {
+	logs     map[string]string
+	errs     map[string]error
+	lastOpts v12.PodLogOptions
+}
+
+func (g *fakeLogsGetter) GetLogs(ctx context.Context, pod v12.Pod, opts v12.PodLogOptions) ([]byte, error) {
+	g.lastOpts = opts
+	if err, ok := g.errs[pod.Name]; ok {
+		return nil, err
+	}
+	return []byte(g.logs[pod.Name]), nil
+}
+
+type fakeSink struct {
+	stored map[string][]logs.ContainerLogs
+	err    error
+}
+
+func (s *fakeSink) Store(ctx context.Context, suite v1alpha1.GenericTestSuite, pod v12.Pod, containerLogs []logs.ContainerLogs) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	if s.stored == nil {
+		s.stored = make(map[string][]logs.ContainerLogs)
+	}
+	s.stored[pod.Name] = containerLogs
+	return "fake://" + pod.Name, nil
+}
@@ -0,0 +1,109 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// maxConfigMapDataSize leaves room for metadata within the 1MiB limit of a ConfigMap
+const maxConfigMapDataSize = 1000 * 1024
+
+// truncatedNote is prepended to logs that were truncated to fit in the ConfigMap
+const truncatedNote = "[logs truncated by Octopus]\n"
+
+// ConfigMapSink stores logs in a ConfigMap with the same name and namespace as the testing pod.
+// Every container is stored under a key equal to the container name.
+// ConfigMaps are owned by the suite, so they are removed together with it.
+// Logs that do not fit in a single ConfigMap are truncated, keeping their latest lines.
+type ConfigMapSink struct {
+	cli    client.Client
+	scheme *runtime.Scheme
+}
+
+func NewConfigMapSink(cli client.Client, scheme *runtime.Scheme) *ConfigMapSink {
+	return &ConfigMapSink{cli: cli, scheme: scheme}
+}
+
+func (s *ConfigMapSink) Store(ctx context.Context, suite v1alpha1.GenericTestSuite, pod v1.Pod, logs []ContainerLogs) (string, error) {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			Labels: map[string]string{
+				v1alpha1.LabelKeyCreatedByOctopus: "true",
+				v1alpha1.LabelKeySuiteName:        suite.GetName(),
+				v1alpha1.LabelKeyTestDefName:      pod.Labels[v1alpha1.LabelKeyTestDefName],
+			},
+		},
+		Data: make(map[string]string, len(logs)),
+	}
+	if err := controllerutil.SetOwnerReference(suite, cm, s.scheme); err != nil {
+		return "", errors.Wrapf(err, "while setting owner reference of config map with logs of testing pod [name: %s, namespace: %s]", pod.Name, pod.Namespace)
+	}
+	for _, l := range truncateLogs(logs, maxConfigMapDataSize) {
+		cm.Data[l.Container] = string(l.Logs)
+	}
+
+	err := s.cli.Create(ctx, cm)
+	if k8serrors.IsAlreadyExists(err) {
+		err = s.update(ctx, cm)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "while storing logs of testing pod [name: %s, namespace: %s] in a config map", pod.Name, pod.Namespace)
+	}
+	return fmt.Sprintf("configmap://%s/%s", cm.Namespace, cm.Name), nil
+}
+
+// update overrides logs stored by a previous attempt, which failed before the reference was saved in the suite status
+func (s *ConfigMapSink) update(ctx context.Context, cm *v1.ConfigMap) error {
+	existing := &v1.ConfigMap{}
+	if err := s.cli.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, existing); err != nil {
+		return err
+	}
+	existing.Labels = cm.Labels
+	existing.OwnerReferences = cm.OwnerReferences
+	existing.Data = cm.Data
+	return s.cli.Update(ctx, existing)
+}
+
+// truncateLogs shares the limit between containers, so that short logs are kept whole
+// and only the longest ones lose their beginning. The note about truncation counts towards the limit.
+func truncateLogs(logs []ContainerLogs, limit int) []ContainerLogs {
+	sorted := make([]ContainerLogs, len(logs))
+	copy(sorted, logs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Container)+len(sorted[i].Logs) < len(sorted[j].Container)+len(sorted[j].Logs)
+	})
+
+	out := make([]ContainerLogs, 0, len(sorted))
+	remaining := limit
+	for idx, l := range sorted {
+		share := remaining/(len(sorted)-idx) - len(l.Container)
+		if share < 0 {
+			share = 0
+		}
+		if len(l.Logs) > share {
+			note := truncatedNote
+			if len(note) > share {
+				note = note[:share]
+			}
+			truncated := make([]byte, 0, share)
+			truncated = append(truncated, note...)
+			l.Logs = append(truncated, l.Logs[len(l.Logs)-(share-len(note)):]...)
+		}
+		remaining -= len(l.Container) + len(l.Logs)
+		out = append(out, l)
+	}
+	return out
+}
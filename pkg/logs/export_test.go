@@ -0,0 +1,3 @@
+package logs
+
+var TruncateLogs = truncateLogs
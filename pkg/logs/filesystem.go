@@ -0,0 +1,36 @@
+package logs
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+)
+
+// FilesystemSink stores logs in files, e.g. on a mounted PersistentVolume.
+// Logs of a container are stored in the <dir>/<pod namespace>/<pod name>/<container name>.log file.
+type FilesystemSink struct {
+	dir string
+}
+
+func NewFilesystemSink(dir string) *FilesystemSink {
+	return &FilesystemSink{dir: dir}
+}
+
+func (s *FilesystemSink) Store(ctx context.Context, suite v1alpha1.GenericTestSuite, pod v1.Pod, logs []ContainerLogs) (string, error) {
+	podDir := filepath.Join(s.dir, pod.Namespace, pod.Name)
+	if err := os.MkdirAll(podDir, 0755); err != nil {
+		return "", errors.Wrapf(err, "while creating directory [%s] for logs of testing pod", podDir)
+	}
+	for _, l := range logs {
+		path := filepath.Join(podDir, l.Container+".log")
+		if err := ioutil.WriteFile(path, l.Logs, 0644); err != nil {
+			return "", errors.Wrapf(err, "while writing logs of container [%s] to file [%s]", l.Container, path)
+		}
+	}
+	return "file://" + podDir, nil
+}
@@ -0,0 +1,46 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/config"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SinkNone       = "none"
+	SinkConfigMap  = "configmap"
+	SinkFilesystem = "filesystem"
+)
+
+// ContainerLogs holds logs of a single container of a testing pod
+type ContainerLogs struct {
+	Container string
+	Logs      []byte
+}
+
+// Sink persists logs of a testing pod and returns their location, which is stored in the TestExecution.
+type Sink interface {
+	Store(ctx context.Context, suite v1alpha1.GenericTestSuite, pod v1.Pod, logs []ContainerLogs) (string, error)
+}
+
+// NewSink returns the sink selected in the configuration. It returns nil if logs collection is disabled.
+func NewSink(cfg config.LogsConfig, cli client.Client, scheme *runtime.Scheme) (Sink, error) {
+	switch cfg.Sink {
+	case "", SinkNone:
+		return nil, nil
+	case SinkConfigMap:
+		return NewConfigMapSink(cli, scheme), nil
+	case SinkFilesystem:
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("directory for the [%s] logs sink is not provided", SinkFilesystem)
+		}
+		return NewFilesystemSink(cfg.Dir), nil
+	default:
+		return nil, fmt.Errorf("unknown logs sink [%s]", cfg.Sink)
+	}
+}
@@ -0,0 +1,170 @@
+package logs_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/config"
+	"github.com/kyma-incubator/octopus/pkg/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigMapSink(t *testing.T) {
+	// GIVEN
+	suite := &v1alpha1.ClusterTestSuite{ObjectMeta: v1.ObjectMeta{Name: "test-all", UID: "suite-uid"}}
+	pod := givenPod("oct-tp-test-all-test-a-0", "test-a", "main", "sidecar")
+	fakeCli := fake.NewFakeClient()
+	sut := logs.NewConfigMapSink(fakeCli, givenScheme(t))
+
+	// WHEN
+	ref, err := sut.Store(context.TODO(), suite, pod, []logs.ContainerLogs{
+		{Container: "main", Logs: []byte("main logs")},
+		{Container: "sidecar", Logs: []byte("sidecar logs")},
+	})
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, "configmap://default/oct-tp-test-all-test-a-0", ref)
+	var cm v12.ConfigMap
+	require.NoError(t, fakeCli.Get(context.TODO(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, &cm))
+	assert.Equal(t, map[string]string{"main": "main logs", "sidecar": "sidecar logs"}, cm.Data)
+	assert.Equal(t, "test-all", cm.Labels[v1alpha1.LabelKeySuiteName])
+	assert.Equal(t, "test-a", cm.Labels[v1alpha1.LabelKeyTestDefName])
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, "ClusterTestSuite", cm.OwnerReferences[0].Kind)
+	assert.Equal(t, "test-all", cm.OwnerReferences[0].Name)
+	assert.Equal(t, types.UID("suite-uid"), cm.OwnerReferences[0].UID)
+
+	// WHEN
+	_, err = sut.Store(context.TODO(), suite, pod, []logs.ContainerLogs{{Container: "main", Logs: []byte("new logs")}})
+
+	// THEN
+	require.NoError(t, err)
+	var updated v12.ConfigMap
+	require.NoError(t, fakeCli.Get(context.TODO(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, &updated))
+	assert.Equal(t, map[string]string{"main": "new logs"}, updated.Data)
+	assert.Equal(t, cm.OwnerReferences, updated.OwnerReferences)
+}
+
+func TestConfigMapSinkTruncatesLogs(t *testing.T) {
+	// GIVEN
+	suite := &v1alpha1.ClusterTestSuite{ObjectMeta: v1.ObjectMeta{Name: "test-all"}}
+	pod := givenPod("oct-tp-test-all-test-a-0", "test-a", "main", "sidecar")
+	fakeCli := fake.NewFakeClient()
+	sut := logs.NewConfigMapSink(fakeCli, givenScheme(t))
+	mainLogs := strings.Repeat("a", 2*1024*1024) + "last line"
+
+	// WHEN
+	_, err := sut.Store(context.TODO(), suite, pod, []logs.ContainerLogs{
+		{Container: "main", Logs: []byte(mainLogs)},
+		{Container: "sidecar", Logs: []byte("sidecar logs")},
+	})
+
+	// THEN
+	require.NoError(t, err)
+	var cm v12.ConfigMap
+	require.NoError(t, fakeCli.Get(context.TODO(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, &cm))
+	assert.Equal(t, "sidecar logs", cm.Data["sidecar"])
+	assert.True(t, strings.HasPrefix(cm.Data["main"], "[logs truncated by Octopus]\n"))
+	assert.True(t, strings.HasSuffix(cm.Data["main"], "last line"))
+	size := 0
+	for k, v := range cm.Data {
+		size += len(k) + len(v)
+	}
+	assert.True(t, size <= 1000*1024)
+}
+
+func TestTruncateLogs(t *testing.T) {
+	// GIVEN
+	var given []logs.ContainerLogs
+	for i := 0; i < 50; i++ {
+		given = append(given, logs.ContainerLogs{Container: fmt.Sprintf("container-%02d", i), Logs: []byte(strings.Repeat("a", 100))})
+	}
+	given = append(given, logs.ContainerLogs{Container: "short", Logs: []byte("ok")})
+
+	// WHEN
+	actual := logs.TruncateLogs(given, 1000)
+
+	// THEN
+	require.Len(t, actual, len(given))
+	size := 0
+	for _, l := range actual {
+		size += len(l.Container) + len(l.Logs)
+		if l.Container == "short" {
+			assert.Equal(t, "ok", string(l.Logs))
+		} else {
+			assert.True(t, strings.HasPrefix("[logs truncated by Octopus]\n", string(l.Logs)))
+		}
+	}
+	assert.True(t, size <= 1000)
+}
+
+func TestFilesystemSink(t *testing.T) {
+	// GIVEN
+	dir, err := ioutil.TempDir("", "octopus-logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	suite := &v1alpha1.ClusterTestSuite{ObjectMeta: v1.ObjectMeta{Name: "test-all"}}
+	pod := givenPod("oct-tp-test-all-test-a-0", "test-a", "main")
+	sut := logs.NewFilesystemSink(dir)
+
+	// WHEN
+	ref, err := sut.Store(context.TODO(), suite, pod, []logs.ContainerLogs{{Container: "main", Logs: []byte("main logs")}})
+
+	// THEN
+	require.NoError(t, err)
+	podDir := filepath.Join(dir, "default", "oct-tp-test-all-test-a-0")
+	assert.Equal(t, "file://"+podDir, ref)
+	actual, err := ioutil.ReadFile(filepath.Join(podDir, "main.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "main logs", string(actual))
+}
+
+func TestNewSink(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg         config.LogsConfig
+		expectedNil bool
+		expectedErr string
+	}{
+		"disabled by default": {cfg: config.LogsConfig{}, expectedNil: true},
+		"disabled":            {cfg: config.LogsConfig{Sink: logs.SinkNone}, expectedNil: true},
+		"config map":          {cfg: config.LogsConfig{Sink: logs.SinkConfigMap}},
+		"filesystem":          {cfg: config.LogsConfig{Sink: logs.SinkFilesystem, Dir: "/logs"}},
+		"filesystem without directory": {
+			cfg:         config.LogsConfig{Sink: logs.SinkFilesystem},
+			expectedErr: "directory for the [filesystem] logs sink is not provided",
+		},
+		"unknown": {cfg: config.LogsConfig{Sink: "s3"}, expectedErr: "unknown logs sink [s3]"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			sink, err := logs.NewSink(tc.cfg, fake.NewFakeClient(), givenScheme(t))
+			// THEN
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedNil, sink == nil)
+		})
+	}
+}
+
+func givenScheme(t *testing.T) *runtime.Scheme {
+	sch := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(sch))
+	return sch
+}
@@ -0,0 +1,232 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "octopus"
+
+	labelSuite          = "suite"
+	labelSuiteNamespace = "suite_namespace"
+	labelTestDefinition = "test_definition"
+	labelTestNamespace  = "test_namespace"
+	labelVariant        = "variant"
+	labelResult         = "result"
+)
+
+var durationBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200}
+
+// Recorder exposes Prometheus metrics of suites, tests and executions.
+// Metrics are calculated from changes between the previous and the current status of a suite.
+type Recorder struct {
+	nowProvider func() time.Time
+
+	suitesTotal       *prometheus.CounterVec
+	suiteDuration     *prometheus.HistogramVec
+	testsTotal        *prometheus.CounterVec
+	executionDuration *prometheus.HistogramVec
+	retriesTotal      *prometheus.CounterVec
+	schedulingLatency *prometheus.HistogramVec
+	runningExecutions *prometheus.GaugeVec
+}
+
+// NewRecorder creates metrics of the recorder. Counters and histograms are not labeled with the name of the suite,
+// because their series are never removed and every suite created by a schedule has a unique name.
+func NewRecorder(nowProvider func() time.Time) *Recorder {
+	suiteLabels := []string{labelSuiteNamespace}
+	testLabels := []string{labelSuiteNamespace, labelTestDefinition, labelTestNamespace, labelVariant}
+	return &Recorder{
+		nowProvider: nowProvider,
+		suitesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "suites_total",
+			Help:      "Number of finished suites by result.",
+		}, append(suiteLabels, labelResult)),
+		suiteDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "suite_duration_seconds",
+			Help:      "Duration of finished suites.",
+			Buckets:   durationBuckets,
+		}, suiteLabels),
+		testsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tests_total",
+			Help:      "Number of finished tests by result. A test represents all executions of a TestDefinition in a suite.",
+		}, append(testLabels, labelResult)),
+		executionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "execution_duration_seconds",
+			Help:      "Duration of finished executions by the phase of the testing pod.",
+			Buckets:   durationBuckets,
+		}, append(testLabels, labelResult)),
+		retriesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "execution_retries_total",
+			Help:      "Number of executions scheduled as a retry of a failed execution.",
+		}, testLabels),
+		schedulingLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "execution_scheduling_latency_seconds",
+			Help:      "Time that testing pods spent in the Pending phase, as observed by the controller.",
+			Buckets:   []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+		}, testLabels),
+		runningExecutions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "running_executions",
+			Help:      "Number of executions with a testing pod in the Pending or Running phase.",
+		}, append([]string{labelSuite}, testLabels...)),
+	}
+}
+
+// DefaultRecorder is registered in the controller-runtime registry, which is exposed on the manager metrics endpoint
+var DefaultRecorder = NewRecorder(time.Now)
+
+func init() {
+	metrics.Registry.MustRegister(DefaultRecorder.Collectors()...)
+}
+
+// Collectors returns all metrics of the recorder
+func (r *Recorder) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		r.suitesTotal,
+		r.suiteDuration,
+		r.testsTotal,
+		r.executionDuration,
+		r.retriesTotal,
+		r.schedulingLatency,
+		r.runningExecutions,
+	}
+}
+
+// Record updates metrics with changes between the previous and the current version of the suite.
+// It has to be called only once the current status is persisted, otherwise transitions can be counted twice.
+func (r *Recorder) Record(prev, curr v1alpha1.GenericTestSuite) {
+	suiteName, suiteNs := curr.GetName(), curr.GetNamespace()
+	prevStat, currStat := prev.GetStatus(), curr.GetStatus()
+	cond, finished := finishedCondition(*currStat)
+
+	for _, res := range currStat.Results {
+		lbls := prometheus.Labels{
+			labelSuiteNamespace: suiteNs,
+			labelTestDefinition: res.Name,
+			labelTestNamespace:  res.Namespace,
+			labelVariant:        res.Variant,
+		}
+		prevRes := findResult(*prevStat, res)
+
+		if isTestFinished(res.Status) && (prevRes == nil || prevRes.Status != res.Status) {
+			r.testsTotal.With(withResult(lbls, string(res.Status))).Inc()
+		}
+
+		running := 0
+		for idx, exec := range res.Executions {
+			var prevExec *v1alpha1.TestExecution
+			if prevRes != nil && idx < len(prevRes.Executions) {
+				prevExec = &prevRes.Executions[idx]
+			}
+			if prevExec == nil && isRetry(*curr.GetSpec(), res.Executions, idx) {
+				r.retriesTotal.With(lbls).Inc()
+			}
+			if wasPending(prevExec) && hasStarted(exec) && exec.StartTime != nil {
+				r.schedulingLatency.With(lbls).Observe(r.nowProvider().Sub(exec.StartTime.Time).Seconds())
+			}
+			if exec.CompletionTime != nil && (prevExec == nil || prevExec.CompletionTime == nil) && exec.StartTime != nil {
+				r.executionDuration.With(withResult(lbls, string(exec.PodPhase))).Observe(exec.CompletionTime.Sub(exec.StartTime.Time).Seconds())
+			}
+			if exec.CompletionTime == nil && (isPending(exec) || exec.PodPhase == v1.PodRunning) {
+				running++
+			}
+		}
+
+		runningLbls := withLabel(lbls, labelSuite, suiteName)
+		if finished {
+			// executions of a finished suite are never updated, so there is no need to keep their series
+			r.runningExecutions.Delete(runningLbls)
+		} else {
+			r.runningExecutions.With(runningLbls).Set(float64(running))
+		}
+	}
+
+	if _, wasFinished := finishedCondition(*prevStat); !finished || wasFinished {
+		return
+	}
+	lbls := prometheus.Labels{labelSuiteNamespace: suiteNs}
+	r.suitesTotal.With(withResult(lbls, string(cond))).Inc()
+	if currStat.StartTime != nil && currStat.CompletionTime != nil {
+		r.suiteDuration.With(lbls).Observe(currStat.CompletionTime.Sub(currStat.StartTime.Time).Seconds())
+	}
+}
+
+func withResult(lbls prometheus.Labels, result string) prometheus.Labels {
+	return withLabel(lbls, labelResult, result)
+}
+
+func withLabel(lbls prometheus.Labels, name, value string) prometheus.Labels {
+	out := prometheus.Labels{name: value}
+	for k, v := range lbls {
+		out[k] = v
+	}
+	return out
+}
+
+func findResult(stat v1alpha1.TestSuiteStatus, res v1alpha1.TestResult) *v1alpha1.TestResult {
+	for idx := range stat.Results {
+		if stat.Results[idx].IsVariantOf(res.Name, res.Namespace, res.Variant) {
+			return &stat.Results[idx]
+		}
+	}
+	return nil
+}
+
+func isTestFinished(st v1alpha1.TestStatus) bool {
+	return st == v1alpha1.TestSucceeded || st == v1alpha1.TestFailed || st == v1alpha1.TestFlaky || st == v1alpha1.TestSkipped || st == v1alpha1.TestUnknown
+}
+
+// isRetry returns true for an execution scheduled by the retry strategy after a failed execution.
+// Executions repeated because of Count are not retries.
+func isRetry(spec v1alpha1.TestSuiteSpec, execs []v1alpha1.TestExecution, idx int) bool {
+	return spec.MaxRetries > 0 && idx > 0 && execs[idx-1].PodPhase == v1.PodFailed
+}
+
+// isPending returns true for executions that are scheduled, but their testing pod has not started yet
+func isPending(exec v1alpha1.TestExecution) bool {
+	return exec.PodPhase == "" || exec.PodPhase == v1.PodPending
+}
+
+// hasStarted returns true for executions with a testing pod that reported leaving the Pending phase.
+// Executions failed by the controller, e.g. interrupted ones or ones with a deleted testing pod,
+// have no container results, because their phase was not reported by the testing pod.
+func hasStarted(exec v1alpha1.TestExecution) bool {
+	switch exec.PodPhase {
+	case v1.PodRunning, v1.PodSucceeded:
+		return true
+	case v1.PodFailed:
+		return len(exec.Containers) > 0
+	}
+	return false
+}
+
+func wasPending(exec *v1alpha1.TestExecution) bool {
+	return exec == nil || isPending(*exec)
+}
+
+func finishedCondition(stat v1alpha1.TestSuiteStatus) (v1alpha1.TestSuiteConditionType, bool) {
+	for _, cond := range stat.Conditions {
+		if cond.Status != v1alpha1.StatusTrue {
+			continue
+		}
+		switch {
+		case cond.Type == v1alpha1.SuiteSucceeded, cond.Type == v1alpha1.SuiteFailed, cond.Type == v1alpha1.SuiteAborted:
+			return cond.Type, true
+		case cond.Type == v1alpha1.SuiteError && cond.Reason != v1alpha1.ReasonErrorOnInitialization:
+			return cond.Type, true
+		}
+	}
+	return "", false
+}
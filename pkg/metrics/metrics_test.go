@@ -0,0 +1,273 @@
+package metrics_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecord(t *testing.T) {
+	// GIVEN
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := start.Add(time.Minute)
+	sut, reg := givenRecorder(t, now)
+
+	prev := &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Spec:       v1alpha1.TestSuiteSpec{MaxRetries: 1},
+		Status: v1alpha1.TestSuiteStatus{
+			StartTime:  timeAt(start, 0),
+			Conditions: []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusTrue}},
+			Results: []v1alpha1.TestResult{
+				{Name: "test-a", Namespace: "default", Status: v1alpha1.TestScheduled, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-tp-test-all-test-a-0", StartTime: timeAt(start, 0)},
+				}},
+				{Name: "test-b", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-tp-test-all-test-b-0", PodPhase: v12.PodRunning, StartTime: timeAt(start, 0)},
+				}},
+			},
+		},
+	}
+	curr := prev.DeepCopy()
+	curr.Status.Results[0].Status = v1alpha1.TestRunning
+	curr.Status.Results[0].Executions[0].PodPhase = v12.PodRunning
+	curr.Status.Results[1].Status = v1alpha1.TestScheduled
+	curr.Status.Results[1].Executions[0].PodPhase = v12.PodFailed
+	curr.Status.Results[1].Executions[0].CompletionTime = timeAt(start, 30)
+	curr.Status.Results[1].Executions = append(curr.Status.Results[1].Executions, v1alpha1.TestExecution{ID: "oct-tp-test-all-test-b-1", StartTime: timeAt(start, 60)})
+
+	// WHEN
+	sut.Record(prev, curr)
+
+	// THEN
+	assert.Equal(t, map[string]float64{
+		`octopus_execution_duration_seconds{result="Failed",suite_namespace="",test_definition="test-b",test_namespace="default",variant=""}`: 1,
+		`octopus_execution_retries_total{suite_namespace="",test_definition="test-b",test_namespace="default",variant=""}`:                    1,
+		`octopus_execution_scheduling_latency_seconds{suite_namespace="",test_definition="test-a",test_namespace="default",variant=""}`:       1,
+		`octopus_running_executions{suite="test-all",suite_namespace="",test_definition="test-a",test_namespace="default",variant=""}`:        1,
+		`octopus_running_executions{suite="test-all",suite_namespace="",test_definition="test-b",test_namespace="default",variant=""}`:        1,
+	}, gather(t, reg))
+
+	// GIVEN
+	finished := curr.DeepCopy()
+	finished.Status.CompletionTime = timeAt(start, 90)
+	finished.Status.Conditions = []v1alpha1.TestSuiteCondition{
+		{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusFalse},
+		{Type: v1alpha1.SuiteFailed, Status: v1alpha1.StatusTrue},
+	}
+	finished.Status.Results[0].Status = v1alpha1.TestSucceeded
+	finished.Status.Results[0].Executions[0].PodPhase = v12.PodSucceeded
+	finished.Status.Results[0].Executions[0].CompletionTime = timeAt(start, 80)
+	finished.Status.Results[1].Status = v1alpha1.TestFailed
+	finished.Status.Results[1].Executions[1].PodPhase = v12.PodFailed
+	finished.Status.Results[1].Executions[1].CompletionTime = timeAt(start, 90)
+	finished.Status.Results[1].Executions[1].Reason = v1alpha1.ExecutionReasonTimedOut
+
+	// WHEN
+	sut.Record(curr, finished)
+	// recording the same status again does not change anything
+	sut.Record(finished, finished)
+
+	// THEN
+	assert.Equal(t, map[string]float64{
+		`octopus_execution_duration_seconds{result="Failed",suite_namespace="",test_definition="test-b",test_namespace="default",variant=""}`:    2,
+		`octopus_execution_duration_seconds{result="Succeeded",suite_namespace="",test_definition="test-a",test_namespace="default",variant=""}`: 1,
+		`octopus_execution_retries_total{suite_namespace="",test_definition="test-b",test_namespace="default",variant=""}`:                       1,
+		`octopus_execution_scheduling_latency_seconds{suite_namespace="",test_definition="test-a",test_namespace="default",variant=""}`:          1,
+		`octopus_suite_duration_seconds{suite_namespace=""}`:                                                                                     1,
+		`octopus_suites_total{result="Failed",suite_namespace=""}`:                                                                               1,
+		`octopus_tests_total{result="Failed",suite_namespace="",test_definition="test-b",test_namespace="default",variant=""}`:                   1,
+		`octopus_tests_total{result="Succeeded",suite_namespace="",test_definition="test-a",test_namespace="default",variant=""}`:                1,
+	}, gather(t, reg))
+}
+
+func TestRecordRepeatedExecutions(t *testing.T) {
+	// GIVEN
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	sut, reg := givenRecorder(t, start.Add(time.Minute))
+	prev := &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Spec:       v1alpha1.TestSuiteSpec{Count: 3},
+		Status: v1alpha1.TestSuiteStatus{
+			StartTime:  timeAt(start, 0),
+			Conditions: []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusTrue}},
+			Results: []v1alpha1.TestResult{
+				{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-tp-test-all-test-a-0", PodPhase: v12.PodRunning, StartTime: timeAt(start, 0)},
+				}},
+			},
+		},
+	}
+	curr := prev.DeepCopy()
+	curr.Status.Results[0].Executions[0].PodPhase = v12.PodFailed
+	curr.Status.Results[0].Executions[0].CompletionTime = timeAt(start, 30)
+	curr.Status.Results[0].Executions = append(curr.Status.Results[0].Executions,
+		v1alpha1.TestExecution{ID: "oct-tp-test-all-test-a-1", StartTime: timeAt(start, 40)},
+		v1alpha1.TestExecution{ID: "oct-tp-test-all-test-a-2", StartTime: timeAt(start, 50)},
+	)
+
+	// WHEN
+	sut.Record(prev, curr)
+
+	// THEN
+	actual := gather(t, reg)
+	assert.NotContains(t, actual, `octopus_execution_retries_total{suite_namespace="",test_definition="test-a",test_namespace="default",variant=""}`)
+	assert.Equal(t, float64(2), actual[`octopus_running_executions{suite="test-all",suite_namespace="",test_definition="test-a",test_namespace="default",variant=""}`])
+}
+
+func TestRecordSchedulingLatency(t *testing.T) {
+	// GIVEN
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	sut, reg := givenRecorder(t, start.Add(time.Minute))
+	exitCode := int32(1)
+	prev := &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Status: v1alpha1.TestSuiteStatus{
+			StartTime:  timeAt(start, 0),
+			Conditions: []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusTrue}},
+			Results: []v1alpha1.TestResult{
+				{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-tp-test-all-test-a-0", PodPhase: v12.PodPending, StartTime: timeAt(start, 0)},
+				}},
+				{Name: "test-b", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-tp-test-all-test-b-0", PodPhase: v12.PodPending, StartTime: timeAt(start, 0)},
+				}},
+				{Name: "test-c", Namespace: "default", Status: v1alpha1.TestScheduled, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-tp-test-all-test-c-0", StartTime: timeAt(start, 0)},
+				}},
+			},
+		},
+	}
+	curr := prev.DeepCopy()
+	// failed by the testing pod
+	curr.Status.Results[0].Executions[0].PodPhase = v12.PodFailed
+	curr.Status.Results[0].Executions[0].CompletionTime = timeAt(start, 30)
+	curr.Status.Results[0].Executions[0].Containers = []v1alpha1.ContainerResult{{Name: "test", ExitCode: &exitCode}}
+	// aborted by the controller
+	curr.Status.Results[1].Executions[0].PodPhase = v12.PodFailed
+	curr.Status.Results[1].Executions[0].CompletionTime = timeAt(start, 30)
+	curr.Status.Results[1].Executions[0].Reason = v1alpha1.ExecutionReasonAborted
+	// deleted out-of-band
+	curr.Status.Results[2].Executions[0].PodPhase = v12.PodFailed
+	curr.Status.Results[2].Executions[0].CompletionTime = timeAt(start, 30)
+	curr.Status.Results[2].Executions[0].Reason = v1alpha1.ExecutionReasonPodDeleted
+
+	// WHEN
+	sut.Record(prev, curr)
+
+	// THEN
+	actual := gather(t, reg)
+	assert.Equal(t, float64(1), actual[`octopus_execution_scheduling_latency_seconds{suite_namespace="",test_definition="test-a",test_namespace="default",variant=""}`])
+	assert.NotContains(t, actual, `octopus_execution_scheduling_latency_seconds{suite_namespace="",test_definition="test-b",test_namespace="default",variant=""}`)
+	assert.NotContains(t, actual, `octopus_execution_scheduling_latency_seconds{suite_namespace="",test_definition="test-c",test_namespace="default",variant=""}`)
+}
+
+func TestRecordSkippedOnInitialization(t *testing.T) {
+	// GIVEN
+	sut, reg := givenRecorder(t, time.Now())
+	prev := &v1alpha1.TestSuite{ObjectMeta: v1.ObjectMeta{Name: "test-all", Namespace: "team-a"}}
+	curr := prev.DeepCopy()
+	curr.Status.Conditions = []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusTrue}}
+	curr.Status.Results = []v1alpha1.TestResult{
+		{Name: "test-a", Namespace: "team-a", Status: v1alpha1.TestNotYetScheduled},
+		{Name: "test-b", Namespace: "team-a", Status: v1alpha1.TestSkipped},
+	}
+
+	// WHEN
+	sut.Record(prev, curr)
+
+	// THEN
+	assert.Equal(t, map[string]float64{
+		`octopus_running_executions{suite="test-all",suite_namespace="team-a",test_definition="test-a",test_namespace="team-a",variant=""}`: 0,
+		`octopus_running_executions{suite="test-all",suite_namespace="team-a",test_definition="test-b",test_namespace="team-a",variant=""}`: 0,
+		`octopus_tests_total{result="Skipped",suite_namespace="team-a",test_definition="test-b",test_namespace="team-a",variant=""}`:        1,
+	}, gather(t, reg))
+}
+
+func TestRecordSuitesCreatedBySchedule(t *testing.T) {
+	// GIVEN
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	sut, reg := givenRecorder(t, start)
+
+	for _, name := range []string{"nightly-20190101-1000", "nightly-20190102-1000"} {
+		prev := &v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Status: v1alpha1.TestSuiteStatus{
+				StartTime:  timeAt(start, 0),
+				Conditions: []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusTrue}},
+				Results:    []v1alpha1.TestResult{{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning}},
+			},
+		}
+		curr := prev.DeepCopy()
+		curr.Status.Conditions = []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteSucceeded, Status: v1alpha1.StatusTrue}}
+		curr.Status.CompletionTime = timeAt(start, 60)
+		curr.Status.Results[0].Status = v1alpha1.TestSucceeded
+
+		// WHEN
+		sut.Record(prev, curr)
+	}
+
+	// THEN
+	assert.Equal(t, map[string]float64{
+		`octopus_suite_duration_seconds{suite_namespace=""}`:                                                                      2,
+		`octopus_suites_total{result="Succeeded",suite_namespace=""}`:                                                             2,
+		`octopus_tests_total{result="Succeeded",suite_namespace="",test_definition="test-a",test_namespace="default",variant=""}`: 2,
+	}, gather(t, reg))
+}
+
+func givenRecorder(t *testing.T, now time.Time) (*metrics.Recorder, *prometheus.Registry) {
+	sut := metrics.NewRecorder(func() time.Time { return now })
+	reg := prometheus.NewRegistry()
+	require.NoError(t, registerAll(reg, sut.Collectors()))
+	return sut, reg
+}
+
+func registerAll(reg *prometheus.Registry, collectors []prometheus.Collector) error {
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gather returns values of counters and gauges, and number of observations of histograms
+func gather(t *testing.T, reg *prometheus.Registry) map[string]float64 {
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	out := map[string]float64{}
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			var lbls []string
+			for _, lp := range m.GetLabel() {
+				lbls = append(lbls, fmt.Sprintf("%s=%q", lp.GetName(), lp.GetValue()))
+			}
+			sort.Strings(lbls)
+			key := fmt.Sprintf("%s{%s}", mf.GetName(), strings.Join(lbls, ","))
+			switch {
+			case m.Counter != nil:
+				out[key] = m.GetCounter().GetValue()
+			case m.Gauge != nil:
+				out[key] = m.GetGauge().GetValue()
+			case m.Histogram != nil:
+				out[key] = float64(m.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return out
+}
+
+func timeAt(start time.Time, seconds int) *v1.Time {
+	t := v1.NewTime(start.Add(time.Duration(seconds) * time.Second))
+	return &t
+}
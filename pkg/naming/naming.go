@@ -0,0 +1,13 @@
+// Package naming provides names of tests shared by testing pods and reports
+package naming
+
+import "fmt"
+
+// TestName returns the name of the test used in testing pod names and reports, which includes the variant from
+// the TestDefinition matrix
+func TestName(defName, variant string) string {
+	if variant == "" {
+		return defName
+	}
+	return fmt.Sprintf("%s-%s", defName, variant)
+}
@@ -0,0 +1,13 @@
+package naming_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/octopus/pkg/naming"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestName(t *testing.T) {
+	assert.Equal(t, "test-db", naming.TestName("test-db", ""))
+	assert.Equal(t, "test-db-postgres", naming.TestName("test-db", "postgres"))
+}
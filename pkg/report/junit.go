@@ -0,0 +1,232 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/naming"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite groups test cases created from the results of a single suite
+type JUnitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []JUnitProperty `xml:"properties>property,omitempty"`
+	TestCases  []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// JUnitTestCase represents a single TestDefinition with all its executions
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitMessage `xml:"failure,omitempty"`
+	Error     *JUnitMessage `xml:"error,omitempty"`
+	Skipped   *JUnitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type JUnitMessage struct {
+	Message  string `xml:"message,attr,omitempty"`
+	Type     string `xml:"type,attr,omitempty"`
+	Contents string `xml:",chardata"`
+}
+
+// NewJUnit converts results of the suite into a JUnit report. Every TestDefinition, or every variant of it, is represented by a single test case,
+// retries are listed in the test case output. Tests that have not finished yet are reported as skipped,
+// tests with unknown status as errors. Flaky tests are reported as failures only if they fail the suite.
+func NewJUnit(suite v1alpha1.GenericTestSuite) JUnitTestSuites {
+	status := suite.GetStatus()
+	ts := JUnitTestSuite{
+		Name: suite.GetName(),
+		Time: formatDuration(duration(status.StartTime, status.CompletionTime)),
+		Properties: []JUnitProperty{
+			{Name: "kind", Value: kindOf(suite)},
+			{Name: "namespace", Value: suite.GetNamespace()},
+		},
+	}
+	if status.StartTime != nil {
+		ts.Timestamp = status.StartTime.UTC().Format(time.RFC3339)
+	}
+
+	for _, res := range status.Results {
+		tc := newTestCase(res, *suite.GetSpec())
+		switch {
+		case tc.Failure != nil:
+			ts.Failures++
+		case tc.Error != nil:
+			ts.Errors++
+		case tc.Skipped != nil:
+			ts.Skipped++
+		}
+		ts.Tests++
+		ts.TestCases = append(ts.TestCases, tc)
+	}
+
+	return JUnitTestSuites{
+		Name:     ts.Name,
+		Tests:    ts.Tests,
+		Failures: ts.Failures,
+		Errors:   ts.Errors,
+		Skipped:  ts.Skipped,
+		Time:     ts.Time,
+		Suites:   []JUnitTestSuite{ts},
+	}
+}
+
+// WriteJUnit writes the JUnit report of the suite as an indented XML document
+func WriteJUnit(w io.Writer, suite v1alpha1.GenericTestSuite) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return errors.Wrap(err, "while writing XML header")
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(NewJUnit(suite)); err != nil {
+		return errors.Wrapf(err, "while encoding JUnit report of suite [%s]", suite.GetName())
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return errors.Wrap(err, "while writing JUnit report")
+	}
+	return nil
+}
+
+func newTestCase(res v1alpha1.TestResult, spec v1alpha1.TestSuiteSpec) JUnitTestCase {
+	tc := JUnitTestCase{
+		Name:      naming.TestName(res.Name, res.Variant),
+		Classname: res.Namespace,
+		SystemOut: executionsSummary(res.Executions),
+	}
+	if len(res.PreviousExecutions) > 0 {
+		tc.SystemOut += "\nprevious runs:\n" + executionsSummary(res.PreviousExecutions)
+	}
+
+	var total time.Duration
+	for _, ex := range res.Executions {
+		total += duration(ex.StartTime, ex.CompletionTime)
+	}
+	tc.Time = formatDuration(total)
+
+	switch res.Status {
+	case v1alpha1.TestSucceeded:
+	case v1alpha1.TestFailed:
+		tc.Failure = failureMessage(res.Executions)
+	case v1alpha1.TestFlaky:
+		// flaky tests fail the suite only if they are not retried
+		if spec.IsTestFailed(res.Status) {
+			tc.Failure = failureMessage(res.Executions)
+			tc.Failure.Type = string(v1alpha1.TestFlaky)
+		}
+		tc.SystemOut = fmt.Sprintf("test is flaky, failed %d of %d attempt(s)\n", countFailed(res.Executions), len(res.Executions)) + tc.SystemOut
+	case v1alpha1.TestSkipped:
+		tc.Skipped = &JUnitMessage{Message: "test skipped"}
+		if res.Message != "" {
+			tc.Skipped.Message = res.Message
+		}
+	case v1alpha1.TestUnknown:
+		tc.Error = &JUnitMessage{Message: "status of the test is unknown"}
+	default:
+		tc.Skipped = &JUnitMessage{Message: fmt.Sprintf("test has not finished, status: %s", res.Status)}
+	}
+	return tc
+}
+
+// failureMessage is built from the last failed execution
+func failureMessage(executions []v1alpha1.TestExecution) *JUnitMessage {
+	msg := &JUnitMessage{Message: "test failed"}
+	for i := len(executions) - 1; i >= 0; i-- {
+		ex := executions[i]
+		if ex.PodPhase != v1.PodFailed {
+			continue
+		}
+		if ex.Message != "" {
+			msg.Message = ex.Message
+		}
+		msg.Type = ex.Reason
+		msg.Contents = fmt.Sprintf("execution %s failed after %d attempt(s)", ex.ID, len(executions))
+		if ex.LogsRef != "" {
+			msg.Contents += fmt.Sprintf(", logs: %s", ex.LogsRef)
+		}
+		for _, c := range ex.Containers {
+			if c.Message != "" {
+				msg.Contents += fmt.Sprintf("\ntermination message of container %s: %s", c.Name, c.Message)
+			}
+		}
+		break
+	}
+	return msg
+}
+
+func countFailed(executions []v1alpha1.TestExecution) int {
+	out := 0
+	for _, ex := range executions {
+		if ex.PodPhase == v1.PodFailed {
+			out++
+		}
+	}
+	return out
+}
+
+func executionsSummary(executions []v1alpha1.TestExecution) string {
+	var lines []string
+	for i, ex := range executions {
+		line := fmt.Sprintf("attempt %d: %s %s", i+1, ex.ID, ex.PodPhase)
+		if ex.Reason != "" {
+			line += fmt.Sprintf(" (%s)", ex.Reason)
+		}
+		if ex.Message != "" {
+			line += ": " + ex.Message
+		}
+		if ex.LogsRef != "" {
+			line += ", logs: " + ex.LogsRef
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func duration(start, completion *metav1.Time) time.Duration {
+	if start == nil || completion == nil {
+		return 0
+	}
+	return completion.Sub(start.Time)
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func kindOf(suite v1alpha1.GenericTestSuite) string {
+	if v1alpha1.IsNamespaced(suite) {
+		return "TestSuite"
+	}
+	return "ClusterTestSuite"
+}
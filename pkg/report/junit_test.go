@@ -0,0 +1,300 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewJUnit(t *testing.T) {
+	// GIVEN
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	suite := &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Status: v1alpha1.TestSuiteStatus{
+			StartTime:      timeAt(start, 0),
+			CompletionTime: timeAt(start, 90),
+			Results: []v1alpha1.TestResult{
+				{
+					Name:      "test-a",
+					Namespace: "default",
+					Status:    v1alpha1.TestSucceeded,
+					Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-a-1", PodPhase: v12.PodSucceeded, StartTime: timeAt(start, 0), CompletionTime: timeAt(start, 10)},
+					},
+					PreviousExecutions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-a-0", PodPhase: v12.PodFailed},
+					},
+				},
+				{
+					Name:      "test-b",
+					Namespace: "default",
+					Status:    v1alpha1.TestFailed,
+					Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-b-0", PodPhase: v12.PodFailed, StartTime: timeAt(start, 10), CompletionTime: timeAt(start, 20)},
+						{ID: "oct-tp-test-all-test-b-1", PodPhase: v12.PodFailed, StartTime: timeAt(start, 20), CompletionTime: timeAt(start, 25), Reason: "Timeout", Message: "execution took longer than 5s", LogsRef: "configmap://default/oct-tp-test-all-test-b-1"},
+					},
+				},
+				{
+					Name:      "test-c",
+					Namespace: "kyma-system",
+					Status:    v1alpha1.TestSkipped,
+				},
+				{
+					Name:      "test-d",
+					Namespace: "default",
+					Status:    v1alpha1.TestRunning,
+					Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-d-0", PodPhase: v12.PodRunning, StartTime: timeAt(start, 25)},
+					},
+				},
+				{
+					Name:      "test-e",
+					Namespace: "default",
+					Status:    v1alpha1.TestUnknown,
+				},
+				{
+					Name:      "test-f",
+					Namespace: "default",
+					Status:    v1alpha1.TestFlaky,
+					Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-f-0", PodPhase: v12.PodFailed, Message: "exit code 1"},
+						{ID: "oct-tp-test-all-test-f-1", PodPhase: v12.PodSucceeded},
+					},
+				},
+			},
+		},
+	}
+
+	// WHEN
+	actual := report.NewJUnit(suite)
+
+	// THEN
+	assert.Equal(t, 6, actual.Tests)
+	assert.Equal(t, 2, actual.Failures)
+	assert.Equal(t, 1, actual.Errors)
+	assert.Equal(t, 2, actual.Skipped)
+	assert.Equal(t, "90.000", actual.Time)
+	require.Len(t, actual.Suites, 1)
+	ts := actual.Suites[0]
+	assert.Equal(t, "test-all", ts.Name)
+	assert.Equal(t, "2019-01-01T10:00:00Z", ts.Timestamp)
+	require.Len(t, ts.TestCases, 6)
+
+	assert.Equal(t, report.JUnitTestCase{
+		Name:      "test-a",
+		Classname: "default",
+		Time:      "10.000",
+		SystemOut: "attempt 1: oct-tp-test-all-test-a-1 Succeeded\nprevious runs:\nattempt 1: oct-tp-test-all-test-a-0 Failed",
+	}, ts.TestCases[0])
+
+	failed := ts.TestCases[1]
+	assert.Equal(t, "15.000", failed.Time)
+	require.NotNil(t, failed.Failure)
+	assert.Equal(t, "execution took longer than 5s", failed.Failure.Message)
+	assert.Equal(t, "Timeout", failed.Failure.Type)
+	assert.Equal(t, "execution oct-tp-test-all-test-b-1 failed after 2 attempt(s), logs: configmap://default/oct-tp-test-all-test-b-1", failed.Failure.Contents)
+	assert.Equal(t, "attempt 1: oct-tp-test-all-test-b-0 Failed\nattempt 2: oct-tp-test-all-test-b-1 Failed (Timeout): execution took longer than 5s, logs: configmap://default/oct-tp-test-all-test-b-1", failed.SystemOut)
+
+	require.NotNil(t, ts.TestCases[2].Skipped)
+	assert.Equal(t, "kyma-system", ts.TestCases[2].Classname)
+	require.NotNil(t, ts.TestCases[3].Skipped)
+	assert.Equal(t, "test has not finished, status: Running", ts.TestCases[3].Skipped.Message)
+	assert.Equal(t, "0.000", ts.TestCases[3].Time)
+	require.NotNil(t, ts.TestCases[4].Error)
+	require.NotNil(t, ts.TestCases[5].Failure)
+	assert.Equal(t, "Flaky", ts.TestCases[5].Failure.Type)
+	assert.Equal(t, "exit code 1", ts.TestCases[5].Failure.Message)
+	assert.Equal(t, "test is flaky, failed 1 of 2 attempt(s)\nattempt 1: oct-tp-test-all-test-f-0 Failed: exit code 1\nattempt 2: oct-tp-test-all-test-f-1 Succeeded", ts.TestCases[5].SystemOut)
+}
+
+func TestNewJUnitFlakyTestWithRetries(t *testing.T) {
+	// GIVEN
+	suite := &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Spec:       v1alpha1.TestSuiteSpec{MaxRetries: 1},
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				{Name: "test-a", Namespace: "default", Status: v1alpha1.TestFlaky, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-tp-test-all-test-a-0", PodPhase: v12.PodFailed},
+					{ID: "oct-tp-test-all-test-a-1", PodPhase: v12.PodSucceeded},
+				}},
+			},
+		},
+	}
+
+	// WHEN
+	actual := report.NewJUnit(suite)
+
+	// THEN
+	assert.Equal(t, 0, actual.Failures)
+	require.Len(t, actual.Suites[0].TestCases, 1)
+	tc := actual.Suites[0].TestCases[0]
+	assert.Nil(t, tc.Failure)
+	assert.Nil(t, tc.Skipped)
+	assert.Contains(t, tc.SystemOut, "test is flaky, failed 1 of 2 attempt(s)")
+}
+
+func TestNewJUnitWithVariants(t *testing.T) {
+	// GIVEN
+	suite := &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				{Name: "test-db", Namespace: "default", Variant: "postgres", Status: v1alpha1.TestSucceeded},
+				{Name: "test-db", Namespace: "default", Variant: "mysql", Status: v1alpha1.TestFailed},
+			},
+		},
+	}
+
+	// WHEN
+	actual := report.NewJUnit(suite)
+
+	// THEN
+	require.Len(t, actual.Suites[0].TestCases, 2)
+	assert.Equal(t, "test-db-postgres", actual.Suites[0].TestCases[0].Name)
+	assert.Nil(t, actual.Suites[0].TestCases[0].Failure)
+	assert.Equal(t, "test-db-mysql", actual.Suites[0].TestCases[1].Name)
+	assert.NotNil(t, actual.Suites[0].TestCases[1].Failure)
+	assert.Equal(t, 1, actual.Failures)
+}
+
+func TestNewJUnitWithTerminationMessage(t *testing.T) {
+	// GIVEN
+	exitCode := int32(1)
+	suite := &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				{Name: "test-a", Namespace: "default", Status: v1alpha1.TestFailed, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-tp-test-all-test-a-0", PodPhase: v12.PodFailed, Message: "Container [test] terminated with exit code [1], reason [Error]",
+						Containers: []v1alpha1.ContainerResult{{Name: "test", ExitCode: &exitCode, Reason: "Error", Message: "assertion failed"}}},
+				}},
+			},
+		},
+	}
+
+	// WHEN
+	actual := report.NewJUnit(suite)
+
+	// THEN
+	require.Len(t, actual.Suites[0].TestCases, 1)
+	failure := actual.Suites[0].TestCases[0].Failure
+	require.NotNil(t, failure)
+	assert.Equal(t, "Container [test] terminated with exit code [1], reason [Error]", failure.Message)
+	assert.Equal(t, "execution oct-tp-test-all-test-a-0 failed after 1 attempt(s)\ntermination message of container test: assertion failed", failure.Contents)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	// GIVEN
+	suite := &v1alpha1.TestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all", Namespace: "default"},
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				{Name: "test-a", Namespace: "default", Status: v1alpha1.TestFailed, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-np-test-all-test-a-0", PodPhase: v12.PodFailed, Message: "exit code <1>"},
+				}},
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+
+	// WHEN
+	err := report.WriteJUnit(buf, suite)
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="test-all" tests="1" failures="1" errors="0" skipped="0" time="0.000">
+  <testsuite name="test-all" tests="1" failures="1" errors="0" skipped="0" time="0.000">
+    <properties>
+      <property name="kind" value="TestSuite"></property>
+      <property name="namespace" value="default"></property>
+    </properties>
+    <testcase name="test-a" classname="default" time="0.000">
+      <failure message="exit code &lt;1&gt;">execution oct-np-test-all-test-a-0 failed after 1 attempt(s)</failure>
+      <system-out>attempt 1: oct-np-test-all-test-a-0 Failed: exit code &lt;1&gt;</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
+
+func TestDecodeSuite(t *testing.T) {
+	t.Run("ClusterTestSuite", func(t *testing.T) {
+		// WHEN
+		suite, err := report.DecodeSuite([]byte(`
+apiVersion: testing.kyma-project.io/v1alpha1
+kind: ClusterTestSuite
+metadata:
+  name: test-all
+status:
+  results:
+  - name: test-a
+    namespace: default
+    status: Succeeded
+    executions:
+    - id: oct-tp-test-all-test-a-0
+      podPhase: Succeeded
+`))
+		// THEN
+		require.NoError(t, err)
+		require.IsType(t, &v1alpha1.ClusterTestSuite{}, suite)
+		assert.Equal(t, "test-all", suite.GetName())
+		require.Len(t, suite.GetStatus().Results, 1)
+		assert.Equal(t, "oct-tp-test-all-test-a-0", suite.GetStatus().Results[0].Executions[0].ID)
+	})
+
+	t.Run("TestSuite", func(t *testing.T) {
+		// WHEN
+		suite, err := report.DecodeSuite([]byte(`{"kind": "TestSuite", "metadata": {"name": "test-all", "namespace": "default"}}`))
+		// THEN
+		require.NoError(t, err)
+		require.IsType(t, &v1alpha1.TestSuite{}, suite)
+		assert.Equal(t, "default", suite.GetNamespace())
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		// WHEN
+		_, err := report.DecodeSuite([]byte(`kind: Pod`))
+		// THEN
+		require.EqualError(t, err, "unsupported kind [Pod], expected ClusterTestSuite or TestSuite")
+	})
+}
+
+func TestFetchSuite(t *testing.T) {
+	// GIVEN
+	sch := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(sch))
+	fakeCli := fake.NewFakeClientWithScheme(sch,
+		&v1alpha1.ClusterTestSuite{ObjectMeta: v1.ObjectMeta{Name: "test-all"}},
+		&v1alpha1.TestSuite{ObjectMeta: v1.ObjectMeta{Name: "test-all", Namespace: "default"}},
+	)
+
+	// WHEN
+	cts, err := report.FetchSuite(context.TODO(), fakeCli, "test-all", "")
+	require.NoError(t, err)
+	ts, err := report.FetchSuite(context.TODO(), fakeCli, "test-all", "default")
+	require.NoError(t, err)
+	_, err = report.FetchSuite(context.TODO(), fakeCli, "missing", "")
+
+	// THEN
+	assert.IsType(t, &v1alpha1.ClusterTestSuite{}, cts)
+	assert.IsType(t, &v1alpha1.TestSuite{}, ts)
+	assert.Error(t, err)
+}
+
+func timeAt(start time.Time, seconds int) *v1.Time {
+	t := v1.NewTime(start.Add(time.Duration(seconds) * time.Second))
+	return &t
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// DecodeSuite reads a ClusterTestSuite or TestSuite from its YAML or JSON representation, e.g. output of `kubectl get -o yaml`
+func DecodeSuite(data []byte) (v1alpha1.GenericTestSuite, error) {
+	var meta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Wrap(err, "while reading kind of the suite")
+	}
+
+	var suite v1alpha1.GenericTestSuite
+	switch meta.Kind {
+	case "ClusterTestSuite":
+		suite = &v1alpha1.ClusterTestSuite{}
+	case "TestSuite":
+		suite = &v1alpha1.TestSuite{}
+	default:
+		return nil, errors.Errorf("unsupported kind [%s], expected ClusterTestSuite or TestSuite", meta.Kind)
+	}
+
+	if err := yaml.Unmarshal(data, suite); err != nil {
+		return nil, errors.Wrapf(err, "while decoding %s", meta.Kind)
+	}
+	return suite, nil
+}
+
+// FetchSuite gets a suite from the cluster. ClusterTestSuite is fetched if namespace is empty, TestSuite otherwise.
+func FetchSuite(ctx context.Context, reader client.Reader, name, namespace string) (v1alpha1.GenericTestSuite, error) {
+	var suite v1alpha1.GenericTestSuite = &v1alpha1.ClusterTestSuite{}
+	if namespace != "" {
+		suite = &v1alpha1.TestSuite{}
+	}
+	if err := reader.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, suite); err != nil {
+		return nil, errors.Wrapf(err, "while fetching suite [name: %s, namespace: %s]", name, namespace)
+	}
+	return suite, nil
+}
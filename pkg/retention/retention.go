@@ -0,0 +1,101 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Service deletes testing pods and suites that are not needed anymore once the suite is finished,
+// according to the pod retention policy and TTLs defined in the suite.
+type Service struct {
+	writer      client.Writer
+	nowProvider func() time.Time
+	log         logr.Logger
+}
+
+func NewService(writer client.Writer, nowProvider func() time.Time, logger logr.Logger) *Service {
+	return &Service{
+		writer:      writer,
+		nowProvider: nowProvider,
+		log:         logger,
+	}
+}
+
+// IsEnabled returns true if the suite defines anything to clean up, so testing pods of finished suites
+// are fetched only when needed.
+func (s *Service) IsEnabled(suite v1alpha1.GenericTestSuite) bool {
+	spec := suite.GetSpec()
+	return (spec.PodRetentionPolicy != "" && spec.PodRetentionPolicy != v1alpha1.PodRetentionKeepAll) ||
+		spec.PodTTLSecondsAfterFinished != nil || spec.TTLSecondsAfterFinished != nil
+}
+
+// CleanUp enforces the retention of a finished suite. TTLs are counted from the suite completion time.
+// It returns after how long the suite has to be cleaned up again because some TTL has not expired yet,
+// or 0 if there is nothing more to clean up.
+func (s *Service) CleanUp(ctx context.Context, suite v1alpha1.GenericTestSuite, pods []v1.Pod) (time.Duration, error) {
+	completion := suite.GetStatus().CompletionTime
+	if completion == nil {
+		return 0, nil
+	}
+	spec := suite.GetSpec()
+	elapsed := s.nowProvider().Sub(completion.Time)
+
+	if spec.TTLSecondsAfterFinished != nil && elapsed >= ttl(*spec.TTLSecondsAfterFinished) {
+		s.log.Info("Deleting finished suite after TTL", "suite", suite.GetName(), "namespace", suite.GetNamespace(), "ttlSecondsAfterFinished", *spec.TTLSecondsAfterFinished)
+		// testing pods are deleted by the garbage collector, because they are owned by the suite
+		if err := s.writer.Delete(ctx, suite, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !k8serrors.IsNotFound(err) {
+			return 0, errors.Wrapf(err, "while deleting suite [%s] after TTL", suite.GetName())
+		}
+		return 0, nil
+	}
+
+	podsExpired := spec.PodTTLSecondsAfterFinished != nil && elapsed >= ttl(*spec.PodTTLSecondsAfterFinished)
+	kept := 0
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if !podsExpired && s.isKept(spec.PodRetentionPolicy, pod) {
+			kept++
+			continue
+		}
+		s.log.Info("Deleting testing pod of finished suite", "suite", suite.GetName(), "podName", pod.Name, "podNs", pod.Namespace, "podRetentionPolicy", spec.PodRetentionPolicy)
+		if err := s.writer.Delete(ctx, pod.DeepCopy()); err != nil && !k8serrors.IsNotFound(err) {
+			return 0, errors.Wrapf(err, "while deleting testing pod [name: %s, namespace: %s] for suite [%s]", pod.Name, pod.Namespace, suite.GetName())
+		}
+	}
+
+	var requeueAfter time.Duration
+	if kept > 0 && spec.PodTTLSecondsAfterFinished != nil {
+		requeueAfter = ttl(*spec.PodTTLSecondsAfterFinished) - elapsed
+	}
+	if spec.TTLSecondsAfterFinished != nil {
+		if remaining := ttl(*spec.TTLSecondsAfterFinished) - elapsed; requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	return requeueAfter, nil
+}
+
+func (s *Service) isKept(policy v1alpha1.PodRetentionPolicy, pod v1.Pod) bool {
+	switch policy {
+	case v1alpha1.PodRetentionKeepNone:
+		return false
+	case v1alpha1.PodRetentionKeepFailed:
+		return pod.Status.Phase == v1.PodFailed
+	default:
+		return true
+	}
+}
+
+func ttl(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
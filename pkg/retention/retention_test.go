@@ -0,0 +1,169 @@
+package retention_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/retention"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	rlog "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var completionTime = time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func TestCleanUp(t *testing.T) {
+	t.Run("keeps only pods of failed executions", func(t *testing.T) {
+		// GIVEN
+		suite := givenFinishedSuite(v1alpha1.TestSuiteSpec{PodRetentionPolicy: v1alpha1.PodRetentionKeepFailed})
+		succeeded := givenPod("oct-tp-test-all-test-a-0", v12.PodSucceeded)
+		failed := givenPod("oct-tp-test-all-test-b-0", v12.PodFailed)
+		cli := givenClient(t, suite, &succeeded, &failed)
+		sut := retention.NewService(cli, fixedTime(completionTime.Add(time.Minute)), rlog.Log)
+
+		// WHEN
+		requeueAfter, err := sut.CleanUp(context.TODO(), suite, []v12.Pod{succeeded, failed})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+		assert.False(t, exists(t, cli, &succeeded))
+		assert.True(t, exists(t, cli, &failed))
+	})
+
+	t.Run("keeps pods until pod TTL expires", func(t *testing.T) {
+		// GIVEN
+		podTTL := int64(3600)
+		suite := givenFinishedSuite(v1alpha1.TestSuiteSpec{PodRetentionPolicy: v1alpha1.PodRetentionKeepAll, PodTTLSecondsAfterFinished: &podTTL})
+		pod := givenPod("oct-tp-test-all-test-a-0", v12.PodSucceeded)
+		cli := givenClient(t, suite, &pod)
+		sut := retention.NewService(cli, fixedTime(completionTime.Add(time.Minute)), rlog.Log)
+
+		// WHEN
+		requeueAfter, err := sut.CleanUp(context.TODO(), suite, []v12.Pod{pod})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 59*time.Minute, requeueAfter)
+		assert.True(t, exists(t, cli, &pod))
+	})
+
+	t.Run("deletes all pods once pod TTL expired", func(t *testing.T) {
+		// GIVEN
+		podTTL := int64(3600)
+		suite := givenFinishedSuite(v1alpha1.TestSuiteSpec{PodRetentionPolicy: v1alpha1.PodRetentionKeepFailed, PodTTLSecondsAfterFinished: &podTTL})
+		failed := givenPod("oct-tp-test-all-test-a-0", v12.PodFailed)
+		cli := givenClient(t, suite, &failed)
+		sut := retention.NewService(cli, fixedTime(completionTime.Add(time.Hour)), rlog.Log)
+
+		// WHEN
+		requeueAfter, err := sut.CleanUp(context.TODO(), suite, []v12.Pod{failed})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+		assert.False(t, exists(t, cli, &failed))
+	})
+
+	t.Run("deletes pods and waits for suite TTL", func(t *testing.T) {
+		// GIVEN
+		suiteTTL := int64(600)
+		suite := givenFinishedSuite(v1alpha1.TestSuiteSpec{PodRetentionPolicy: v1alpha1.PodRetentionKeepNone, TTLSecondsAfterFinished: &suiteTTL})
+		pod := givenPod("oct-tp-test-all-test-a-0", v12.PodFailed)
+		cli := givenClient(t, suite, &pod)
+		sut := retention.NewService(cli, fixedTime(completionTime.Add(time.Minute)), rlog.Log)
+
+		// WHEN
+		requeueAfter, err := sut.CleanUp(context.TODO(), suite, []v12.Pod{pod})
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, 9*time.Minute, requeueAfter)
+		assert.False(t, exists(t, cli, &pod))
+		assert.True(t, exists(t, cli, suite))
+	})
+
+	t.Run("deletes suite once suite TTL expired", func(t *testing.T) {
+		// GIVEN
+		suiteTTL := int64(600)
+		suite := givenFinishedSuite(v1alpha1.TestSuiteSpec{TTLSecondsAfterFinished: &suiteTTL})
+		cli := givenClient(t, suite)
+		sut := retention.NewService(cli, fixedTime(completionTime.Add(10*time.Minute)), rlog.Log)
+
+		// WHEN
+		requeueAfter, err := sut.CleanUp(context.TODO(), suite, nil)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Zero(t, requeueAfter)
+		assert.False(t, exists(t, cli, suite))
+	})
+}
+
+func TestIsEnabled(t *testing.T) {
+	ttl := int64(0)
+	sut := retention.NewService(nil, time.Now, rlog.Log)
+	assert.False(t, sut.IsEnabled(givenFinishedSuite(v1alpha1.TestSuiteSpec{})))
+	assert.False(t, sut.IsEnabled(givenFinishedSuite(v1alpha1.TestSuiteSpec{PodRetentionPolicy: v1alpha1.PodRetentionKeepAll})))
+	assert.True(t, sut.IsEnabled(givenFinishedSuite(v1alpha1.TestSuiteSpec{PodRetentionPolicy: v1alpha1.PodRetentionKeepNone})))
+	assert.True(t, sut.IsEnabled(givenFinishedSuite(v1alpha1.TestSuiteSpec{PodTTLSecondsAfterFinished: &ttl})))
+	assert.True(t, sut.IsEnabled(givenFinishedSuite(v1alpha1.TestSuiteSpec{TTLSecondsAfterFinished: &ttl})))
+}
+
+func givenFinishedSuite(spec v1alpha1.TestSuiteSpec) *v1alpha1.ClusterTestSuite {
+	return &v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Spec:       spec,
+		Status: v1alpha1.TestSuiteStatus{
+			Conditions:     []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteSucceeded, Status: v1alpha1.StatusTrue}},
+			CompletionTime: &v1.Time{Time: completionTime},
+		},
+	}
+}
+
+func givenPod(name string, phase v12.PodPhase) v12.Pod {
+	return v12.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				v1alpha1.LabelKeyCreatedByOctopus: "true",
+				v1alpha1.LabelKeySuiteName:        "test-all",
+			},
+		},
+		Status: v12.PodStatus{Phase: phase},
+	}
+}
+
+func givenClient(t *testing.T, objects ...runtime.Object) client.Client {
+	sch := scheme.Scheme
+	require.NoError(t, v1alpha1.AddToScheme(sch))
+	return fake.NewFakeClientWithScheme(sch, objects...)
+}
+
+func exists(t *testing.T, cli client.Client, obj runtime.Object) bool {
+	accessor, ok := obj.(v1.Object)
+	require.True(t, ok)
+	err := cli.Get(context.TODO(), types.NamespacedName{Name: accessor.GetName(), Namespace: accessor.GetNamespace()}, obj.DeepCopyObject())
+	if k8serrors.IsNotFound(err) {
+		return false
+	}
+	require.NoError(t, err)
+	return true
+}
+
+func fixedTime(now time.Time) func() time.Time {
+	return func() time.Time {
+		return now
+	}
+}
@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+)
+
+// dependenciesSucceeded returns true if all tests that the given test depends on succeeded.
+// A test with variants succeeded if all its variants succeeded.
+func dependenciesSucceeded(suite v1alpha1.GenericTestSuite, tr v1alpha1.TestResult) bool {
+	for _, dep := range tr.DependsOn {
+		found := false
+		for _, res := range suite.GetStatus().Results {
+			if res.Name != dep.Name || res.Namespace != dep.Namespace {
+				continue
+			}
+			if !suite.GetSpec().IsTestSucceeded(res.Status) {
+				return false
+			}
+			found = true
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
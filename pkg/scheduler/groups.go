@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+)
+
+// isConcurrencyGroupBusy returns true if any test from the concurrency group of the given test is in progress
+func isConcurrencyGroupBusy(suite v1alpha1.GenericTestSuite, tr v1alpha1.TestResult) bool {
+	if tr.ConcurrencyGroup == "" {
+		return false
+	}
+	for _, res := range suite.GetStatus().Results {
+		if res.ConcurrencyGroup != tr.ConcurrencyGroup {
+			continue
+		}
+		for _, ex := range res.Executions {
+			if isInProgress(ex) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isInProgress returns true for an execution that is not finished. An execution that was just scheduled
+// has no pod phase yet, because its testing pod may not be visible in the cache, but it occupies resources already.
+func isInProgress(ex v1alpha1.TestExecution) bool {
+	return ex.CompletionTime == nil
+}
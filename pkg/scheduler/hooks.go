@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+)
+
+// getHookToSchedule returns the setup if it was not executed yet, or the teardown once all tests are finished.
+// Returns true if tests cannot be scheduled, because the setup has not succeeded yet or all tests are finished.
+func (s *Service) getHookToSchedule(suite v1alpha1.GenericTestSuite) (*v1alpha1.TestResult, bool) {
+	stat := suite.GetStatus()
+	if s.isTestingFinished(suite) {
+		if stat.Teardown != nil && stat.Teardown.Status == v1alpha1.TestNotYetScheduled {
+			return stat.Teardown.DeepCopy(), true
+		}
+		return nil, true
+	}
+	if stat.Setup != nil && stat.Setup.Status != v1alpha1.TestSucceeded {
+		if stat.Setup.Status == v1alpha1.TestNotYetScheduled {
+			return stat.Setup.DeepCopy(), true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// isTestingFinished returns true if the suite has its final condition and only the teardown can be executed
+func (s *Service) isTestingFinished(suite v1alpha1.GenericTestSuite) bool {
+	for _, cond := range suite.GetStatus().Conditions {
+		if cond.Status != v1alpha1.StatusTrue {
+			continue
+		}
+		switch cond.Type {
+		case v1alpha1.SuiteSucceeded, v1alpha1.SuiteFailed, v1alpha1.SuiteError, v1alpha1.SuiteAborted:
+			return true
+		}
+	}
+	return false
+}
+
+// getHookKind returns "setup" or "teardown" if the TestDefinition is a hook of the suite, otherwise an empty string
+func (s *Service) getHookKind(suite v1alpha1.GenericTestSuite, def v1alpha1.TestDefinition) string {
+	spec := suite.GetSpec()
+	switch {
+	case spec.Setup != nil && spec.Setup.Name == def.Name && spec.Setup.Namespace == def.Namespace:
+		return "setup"
+	case spec.Teardown != nil && spec.Teardown.Name == def.Name && spec.Teardown.Namespace == def.Namespace:
+		return "teardown"
+	}
+	return ""
+}
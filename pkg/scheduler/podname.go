@@ -4,30 +4,77 @@ import (
 	"fmt"
 
 	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/humanerr"
+	"github.com/kyma-incubator/octopus/pkg/naming"
+	"github.com/pkg/errors"
 )
 
 const (
 	TestingPodPrefix = "oct-tp"
+	// NamespacedTestingPodPrefix is used for pods of a TestSuite, so they do not collide with pods of a ClusterTestSuite
+	// with the same name. It has to be as long as TestingPodPrefix, because name length validation does not depend on the suite kind.
+	NamespacedTestingPodPrefix = "oct-np"
+	// max length of k8s name is 253 characters
+	maxPodNameLength = 253
 )
 
 type PodNameGenerator struct{}
 
-func (P *PodNameGenerator) GetName(suite v1alpha1.ClusterTestSuite, def v1alpha1.TestDefinition) (string, error) {
-	// max length of k8s name is 253 characters
-	// TODO (aszecowka): add validation https://github.com/kyma-incubator/octopus/issues/11
+func (P *PodNameGenerator) GetName(suite v1alpha1.GenericTestSuite, def v1alpha1.TestDefinition, variant string) (string, error) {
 	idx := -1
-	for _, tr := range suite.Status.Results {
-		if tr.Name == def.Name && tr.Namespace == def.Namespace {
-			idx = len(tr.Executions)
+	for _, tr := range suite.GetStatus().GetAllResults() {
+		if tr.IsVariantOf(def.Name, def.Namespace, variant) {
+			// executions from previous runs keep their pods, so the index continues after them
+			idx = len(tr.PreviousExecutions) + len(tr.Executions)
 			break
 		}
 	}
 	if idx == -1 {
-		return "", fmt.Errorf("while generating Pod name for suite [%s] and test definition [name: %s, namespace: %s]: the suite has uninitialized status", suite.Name, def.Name, def.Namespace)
+		return "", fmt.Errorf("while generating Pod name for suite [%s] and test definition [name: %s, namespace: %s]: the suite has uninitialized status", suite.GetName(), def.Name, def.Namespace)
 	}
-	name := fmt.Sprintf("%s-%s-%s-%d", TestingPodPrefix, suite.Name, def.Name, idx)
-	if len(name) > 253 {
+	prefix := TestingPodPrefix
+	if v1alpha1.IsNamespaced(suite) {
+		prefix = NamespacedTestingPodPrefix
+	}
+	name := formatPodName(prefix, suite.GetName(), naming.TestName(def.Name, variant), idx)
+	if len(name) > maxPodNameLength {
 		return "", fmt.Errorf("generated pod name is too long: [%s]", name)
 	}
 	return name, nil
 }
+
+// ValidateNameLength checks if names of all testing pods created for given suite and test fit into the limit
+// of k8s name length. For a variant of a test, use naming.TestName to get the name of the test.
+func ValidateNameLength(suiteName, defName string, executions int64) error {
+	if executions < 1 {
+		executions = 1
+	}
+	name := formatPodName(TestingPodPrefix, suiteName, defName, int(executions-1))
+	if len(name) > maxPodNameLength {
+		return fmt.Errorf("testing pod name [%s] would be longer than %d characters", name, maxPodNameLength)
+	}
+	return nil
+}
+
+// ValidateTestNames returns an error if testing pods of different tests would get the same names.
+// It happens when the name of a TestDefinition joined with a variant is the name of another TestDefinition
+// from the same namespace, e.g. "db" with variant "postgres" and "db-postgres".
+func ValidateTestNames(results []v1alpha1.TestResult) error {
+	seen := make(map[string]v1alpha1.TestResult, len(results))
+	for _, tr := range results {
+		key := fmt.Sprintf("%s/%s", tr.Namespace, naming.TestName(tr.Name, tr.Variant))
+		prev, found := seen[key]
+		if !found {
+			seen[key] = tr
+			continue
+		}
+		msg := fmt.Sprintf("Testing pods of Test Definition [name: %s, namespace: %s, variant: %s] and Test Definition [name: %s, namespace: %s, variant: %s] would have the same names, rename one of them or its variant",
+			prev.Name, prev.Namespace, prev.Variant, tr.Name, tr.Namespace, tr.Variant)
+		return humanerr.NewError(errors.New(msg), msg)
+	}
+	return nil
+}
+
+func formatPodName(prefix, suiteName, defName string, idx int) string {
+	return fmt.Sprintf("%s-%s-%s-%d", prefix, suiteName, defName, idx)
+}
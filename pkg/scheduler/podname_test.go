@@ -30,12 +30,64 @@ func TestPodNameGenerator(t *testing.T) {
 			},
 		}
 		// WHEN
-		actual, err := sut.GetName(suite, getTestDefinitionA())
+		actual, err := sut.GetName(&suite, getTestDefinitionA(), "")
 		// THEN
 		require.NoError(t, err)
 		assert.Equal(t, "oct-tp-test-all-test-a-0", actual)
 	})
 
+	t.Run("when test is rerun", func(t *testing.T) {
+		// GIVEN
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "test-all",
+			},
+			Status: v1alpha1.TestSuiteStatus{
+				Results: []v1alpha1.TestResult{
+					{
+						Name:      "test-a",
+						Namespace: "default",
+						PreviousExecutions: []v1alpha1.TestExecution{
+							{ID: "oct-tp-test-all-test-a-0"},
+							{ID: "oct-tp-test-all-test-a-1"},
+						},
+						Executions: []v1alpha1.TestExecution{
+							{ID: "oct-tp-test-all-test-a-2"},
+						},
+					},
+				},
+			},
+		}
+		// WHEN
+		actual, err := sut.GetName(&suite, getTestDefinitionA(), "")
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "oct-tp-test-all-test-a-3", actual)
+	})
+
+	t.Run("when pod for namespaced suite to create", func(t *testing.T) {
+		// GIVEN
+		suite := v1alpha1.TestSuite{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "test-all",
+				Namespace: "default",
+			},
+			Status: v1alpha1.TestSuiteStatus{
+				Results: []v1alpha1.TestResult{
+					{
+						Name:      "test-a",
+						Namespace: "default",
+					},
+				},
+			},
+		}
+		// WHEN
+		actual, err := sut.GetName(&suite, getTestDefinitionA(), "")
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "oct-np-test-all-test-a-0", actual)
+	})
+
 	t.Run("when next pod for test to create", func(t *testing.T) {
 		// GIVEN
 		suite := v1alpha1.ClusterTestSuite{
@@ -61,7 +113,7 @@ func TestPodNameGenerator(t *testing.T) {
 		}
 
 		// WHEN
-		actual, err := sut.GetName(suite, getTestDefinitionA())
+		actual, err := sut.GetName(&suite, getTestDefinitionA(), "")
 		// THEN
 		require.NoError(t, err)
 		assert.Equal(t, "oct-tp-test-all-test-a-2", actual)
@@ -84,7 +136,7 @@ func TestPodNameGenerator(t *testing.T) {
 		}
 
 		// WHEN
-		_, err := sut.GetName(suite, getTestDefinitionA())
+		_, err := sut.GetName(&suite, getTestDefinitionA(), "")
 		// THEN
 		require.Error(t, err)
 	})
@@ -106,7 +158,7 @@ func TestPodNameGenerator(t *testing.T) {
 		}
 
 		// WHEN
-		_, err := sut.GetName(suite, getTestDefinitionA())
+		_, err := sut.GetName(&suite, getTestDefinitionA(), "")
 		// THEN
 		require.Error(t, err)
 		assert.True(t, strings.HasPrefix(err.Error(), "generated pod name is too long"))
@@ -114,6 +166,56 @@ func TestPodNameGenerator(t *testing.T) {
 
 }
 
+func TestValidateNameLength(t *testing.T) {
+	t.Run("accepts names that fit the limit", func(t *testing.T) {
+		assert.NoError(t, scheduler.ValidateNameLength("test-all", "test-a", 10))
+	})
+
+	t.Run("takes into account index of the last execution", func(t *testing.T) {
+		// GIVEN
+		defName := strings.Repeat("a", 253-len("oct-tp-test-all--0"))
+		// WHEN & THEN
+		assert.NoError(t, scheduler.ValidateNameLength("test-all", defName, 1))
+		assert.Error(t, scheduler.ValidateNameLength("test-all", defName, 11))
+	})
+}
+
+func TestValidateTestNames(t *testing.T) {
+	t.Run("accepts variants and definitions with different names", func(t *testing.T) {
+		// GIVEN
+		results := []v1alpha1.TestResult{
+			{Name: "db", Namespace: "default", Variant: "postgres"},
+			{Name: "db", Namespace: "default", Variant: "mysql"},
+			{Name: "db-postgres", Namespace: "other"},
+		}
+		// WHEN & THEN
+		assert.NoError(t, scheduler.ValidateTestNames(results))
+	})
+
+	t.Run("rejects definition with the name of a variant from the same namespace", func(t *testing.T) {
+		// GIVEN
+		results := []v1alpha1.TestResult{
+			{Name: "db", Namespace: "default", Variant: "postgres"},
+			{Name: "db-postgres", Namespace: "default"},
+		}
+		// WHEN
+		err := scheduler.ValidateTestNames(results)
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Test Definition [name: db-postgres, namespace: default, variant: ] would have the same names")
+	})
+
+	t.Run("rejects variants of different definitions with the same names", func(t *testing.T) {
+		// GIVEN
+		results := []v1alpha1.TestResult{
+			{Name: "db", Namespace: "default", Variant: "postgres-ha"},
+			{Name: "db-postgres", Namespace: "default", Variant: "ha"},
+		}
+		// WHEN & THEN
+		assert.Error(t, scheduler.ValidateTestNames(results))
+	})
+}
+
 func getTestDefinitionA() v1alpha1.TestDefinition {
 	return v1alpha1.TestDefinition{
 		ObjectMeta: v1.ObjectMeta{
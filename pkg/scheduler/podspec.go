@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/humanerr"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+)
+
+// injectSuiteSpec merges environment and volumes defined in the suite into the pod spec of a TestDefinition.
+// Values from the suite take precedence over values from the TestDefinition.
+func injectSuiteSpec(spec *v1.PodSpec, suiteSpec v1alpha1.TestSuiteSpec) {
+	for _, vol := range suiteSpec.Volumes {
+		spec.Volumes = setVolume(spec.Volumes, *vol.DeepCopy())
+	}
+	forEachContainer(spec, func(c *v1.Container) {
+		for _, env := range suiteSpec.Env {
+			c.Env = setEnv(c.Env, *env.DeepCopy())
+		}
+		// the last source takes precedence if a key exists in multiple sources
+		for _, src := range suiteSpec.EnvFrom {
+			c.EnvFrom = append(c.EnvFrom, *src.DeepCopy())
+		}
+		for _, mount := range suiteSpec.VolumeMounts {
+			c.VolumeMounts = setVolumeMount(c.VolumeMounts, *mount.DeepCopy())
+		}
+	})
+}
+
+// ValidateVolumeMounts returns an error if a volume mount from the suite refers to a volume that is defined
+// neither in the suite nor in the pod spec of a TestDefinition, because testing pods of such TestDefinition
+// would be invalid
+func ValidateVolumeMounts(suiteSpec v1alpha1.TestSuiteSpec, defs []v1alpha1.TestDefinition) error {
+	if len(suiteSpec.VolumeMounts) == 0 {
+		return nil
+	}
+	for _, def := range defs {
+		volumes := make(map[string]struct{}, len(suiteSpec.Volumes)+len(def.Spec.Template.Spec.Volumes))
+		for _, vol := range suiteSpec.Volumes {
+			volumes[vol.Name] = struct{}{}
+		}
+		for _, vol := range def.Spec.Template.Spec.Volumes {
+			volumes[vol.Name] = struct{}{}
+		}
+		for _, mount := range suiteSpec.VolumeMounts {
+			if _, found := volumes[mount.Name]; found {
+				continue
+			}
+			msg := fmt.Sprintf("Volume mount [name: %s, mountPath: %s] refers to a volume defined neither in the suite nor in Test Definition [name: %s, namespace: %s]",
+				mount.Name, mount.MountPath, def.Name, def.Namespace)
+			return humanerr.NewError(errors.New(msg), msg)
+		}
+	}
+	return nil
+}
+
+// injectParameters adds parameters as environment variables to all containers, sorted by name.
+// Parameters override variables with the same name defined in the template or in the suite.
+func injectParameters(spec *v1.PodSpec, params map[string]string) {
+	if len(params) == 0 {
+		return
+	}
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	forEachContainer(spec, func(c *v1.Container) {
+		for _, name := range names {
+			c.Env = setEnv(c.Env, v1.EnvVar{Name: name, Value: params[name]})
+		}
+	})
+}
+
+func forEachContainer(spec *v1.PodSpec, fn func(c *v1.Container)) {
+	for idx := range spec.InitContainers {
+		fn(&spec.InitContainers[idx])
+	}
+	for idx := range spec.Containers {
+		fn(&spec.Containers[idx])
+	}
+}
+
+func setEnv(env []v1.EnvVar, newVar v1.EnvVar) []v1.EnvVar {
+	for idx := range env {
+		if env[idx].Name == newVar.Name {
+			env[idx] = newVar
+			return env
+		}
+	}
+	return append(env, newVar)
+}
+
+func setVolume(volumes []v1.Volume, newVol v1.Volume) []v1.Volume {
+	for idx := range volumes {
+		if volumes[idx].Name == newVol.Name {
+			volumes[idx] = newVol
+			return volumes
+		}
+	}
+	return append(volumes, newVol)
+}
+
+func setVolumeMount(mounts []v1.VolumeMount, newMount v1.VolumeMount) []v1.VolumeMount {
+	for idx := range mounts {
+		if mounts[idx].MountPath == newMount.MountPath {
+			mounts[idx] = newMount
+			return mounts
+		}
+	}
+	return append(mounts, newMount)
+}
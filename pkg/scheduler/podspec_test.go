@@ -0,0 +1,36 @@
+package scheduler_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/core/v1"
+)
+
+func TestValidateVolumeMounts(t *testing.T) {
+	suiteSpec := v1alpha1.TestSuiteSpec{
+		Volumes:      []v12.Volume{{Name: "certs"}},
+		VolumeMounts: []v12.VolumeMount{{Name: "certs", MountPath: "/etc/certs"}, {Name: "fixtures", MountPath: "/fixtures"}},
+	}
+	withFixtures := getTestDefinitionA()
+	withFixtures.Spec.Template.Spec.Volumes = []v12.Volume{{Name: "fixtures"}}
+
+	t.Run("accepts mounts of volumes from the suite and the test definition", func(t *testing.T) {
+		// WHEN & THEN
+		assert.NoError(t, scheduler.ValidateVolumeMounts(suiteSpec, []v1alpha1.TestDefinition{withFixtures}))
+	})
+
+	t.Run("rejects mount of a volume missing in a test definition", func(t *testing.T) {
+		// GIVEN
+		withoutFixtures := getTestDefinitionA()
+		withoutFixtures.Name = "test-b"
+		// WHEN
+		err := scheduler.ValidateVolumeMounts(suiteSpec, []v1alpha1.TestDefinition{withFixtures, withoutFixtures})
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Volume mount [name: fixtures, mountPath: /fixtures] refers to a volume defined neither in the suite nor in Test Definition [name: test-b, namespace: default]")
+	})
+}
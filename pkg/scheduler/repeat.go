@@ -8,24 +8,24 @@ import (
 // This strategy is used when maxRetries == 0.
 type repeatStrategy struct{}
 
-func (s *repeatStrategy) GetTestToRunConcurrently(suite v1alpha1.ClusterTestSuite) *v1alpha1.TestResult {
+func (s *repeatStrategy) GetTestToRunConcurrently(suite v1alpha1.GenericTestSuite) *v1alpha1.TestResult {
 	return s.getTest(suite, func(tr v1alpha1.TestResult) bool {
 		return tr.DisabledConcurrency == false
 	})
 }
 
-func (s *repeatStrategy) GetTestToRunSequentially(suite v1alpha1.ClusterTestSuite) *v1alpha1.TestResult {
+func (s *repeatStrategy) GetTestToRunSequentially(suite v1alpha1.GenericTestSuite) *v1alpha1.TestResult {
 	return s.getTest(suite, func(tr v1alpha1.TestResult) bool {
 		return tr.DisabledConcurrency == true
 	})
 }
 
-func (s *repeatStrategy) getTest(suite v1alpha1.ClusterTestSuite, match func(tr v1alpha1.TestResult) bool) *v1alpha1.TestResult {
-	for _, tr := range suite.Status.Results {
-		if !match(tr) {
+func (s *repeatStrategy) getTest(suite v1alpha1.GenericTestSuite, match func(tr v1alpha1.TestResult) bool) *v1alpha1.TestResult {
+	for _, tr := range suite.GetStatus().Results {
+		if !match(tr) || tr.Status == v1alpha1.TestSkipped || !dependenciesSucceeded(suite, tr) || isConcurrencyGroupBusy(suite, tr) {
 			continue
 		}
-		if len(tr.Executions) < int(suite.Spec.Count) {
+		if len(tr.Executions) < int(suite.GetSpec().Count) {
 			return &tr
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
 )
 
 func TestRepeatStrategyGetConcurrently(t *testing.T) {
@@ -15,7 +16,7 @@ func TestRepeatStrategyGetConcurrently(t *testing.T) {
 		// GIVEN
 		suite := v1alpha1.ClusterTestSuite{}
 		// WHEN & THEN
-		assert.Nil(t, sut.GetTestToRunConcurrently(suite))
+		assert.Nil(t, sut.GetTestToRunConcurrently(&suite))
 	})
 
 	t.Run("ignore tests with disabled concurrency", func(t *testing.T) {
@@ -42,7 +43,7 @@ func TestRepeatStrategyGetConcurrently(t *testing.T) {
 			},
 		}
 		// WHEN
-		actual := sut.GetTestToRunConcurrently(suite)
+		actual := sut.GetTestToRunConcurrently(&suite)
 		// THEN
 		require.NotNil(t, actual)
 		assert.Equal(t, "test3", actual.Name)
@@ -73,7 +74,35 @@ func TestRepeatStrategyGetConcurrently(t *testing.T) {
 			},
 		}
 		// WHEN
-		actual := sut.GetTestToRunConcurrently(suite)
+		actual := sut.GetTestToRunConcurrently(&suite)
+		// THEN
+		require.NotNil(t, actual)
+		assert.Equal(t, "test2", actual.Name)
+	})
+
+	t.Run("ignore skipped tests", func(t *testing.T) {
+		// GIVEN
+		suite := v1alpha1.ClusterTestSuite{
+			Spec: v1alpha1.TestSuiteSpec{
+				Count: 1,
+			},
+			Status: v1alpha1.TestSuiteStatus{
+				Results: []v1alpha1.TestResult{
+					{
+						Name:                "test1",
+						DisabledConcurrency: false,
+						Status:              v1alpha1.TestSkipped,
+					},
+					{
+						Name:                "test2",
+						DisabledConcurrency: false,
+						Status:              v1alpha1.TestNotYetScheduled,
+					},
+				},
+			},
+		}
+		// WHEN
+		actual := sut.GetTestToRunConcurrently(&suite)
 		// THEN
 		require.NotNil(t, actual)
 		assert.Equal(t, "test2", actual.Name)
@@ -105,7 +134,7 @@ func TestRepeatStrategyGetConcurrently(t *testing.T) {
 			},
 		}
 		// WHEN
-		actual := sut.GetTestToRunConcurrently(suite)
+		actual := sut.GetTestToRunConcurrently(&suite)
 		// THEN
 		require.Nil(t, actual)
 	})
@@ -118,7 +147,7 @@ func TestRepeatStrategyGetSequentially(t *testing.T) {
 		// GIVEN
 		suite := v1alpha1.ClusterTestSuite{Spec: v1alpha1.TestSuiteSpec{Count: 1}}
 		// WHEN & THEN
-		assert.Nil(t, sut.GetTestToRunSequentially(suite))
+		assert.Nil(t, sut.GetTestToRunSequentially(&suite))
 	})
 
 	t.Run("ignore tests with enabled concurrency", func(t *testing.T) {
@@ -143,7 +172,7 @@ func TestRepeatStrategyGetSequentially(t *testing.T) {
 			},
 		}
 		// WHEN
-		actual := sut.GetTestToRunSequentially(suite)
+		actual := sut.GetTestToRunSequentially(&suite)
 		// THEN
 		require.NotNil(t, actual)
 		assert.Equal(t, "test3", actual.Name)
@@ -174,7 +203,35 @@ func TestRepeatStrategyGetSequentially(t *testing.T) {
 			},
 		}
 		// WHEN
-		actual := sut.GetTestToRunSequentially(suite)
+		actual := sut.GetTestToRunSequentially(&suite)
+		// THEN
+		require.NotNil(t, actual)
+		assert.Equal(t, "test2", actual.Name)
+	})
+
+	t.Run("ignore skipped tests", func(t *testing.T) {
+		// GIVEN
+		suite := v1alpha1.ClusterTestSuite{
+			Spec: v1alpha1.TestSuiteSpec{
+				Count: 1,
+			},
+			Status: v1alpha1.TestSuiteStatus{
+				Results: []v1alpha1.TestResult{
+					{
+						Name:                "test1",
+						DisabledConcurrency: true,
+						Status:              v1alpha1.TestSkipped,
+					},
+					{
+						Name:                "test2",
+						DisabledConcurrency: true,
+						Status:              v1alpha1.TestNotYetScheduled,
+					},
+				},
+			},
+		}
+		// WHEN
+		actual := sut.GetTestToRunSequentially(&suite)
 		// THEN
 		require.NotNil(t, actual)
 		assert.Equal(t, "test2", actual.Name)
@@ -206,8 +263,102 @@ func TestRepeatStrategyGetSequentially(t *testing.T) {
 			},
 		}
 		// WHEN
-		actual := sut.GetTestToRunSequentially(suite)
+		actual := sut.GetTestToRunSequentially(&suite)
 		// THEN
 		require.Nil(t, actual)
 	})
 }
+
+func TestRepeatStrategyWithDependencies(t *testing.T) {
+	sut := repeatStrategy{}
+	givenSuite := func(depStatus v1alpha1.TestStatus) v1alpha1.ClusterTestSuite {
+		return v1alpha1.ClusterTestSuite{
+			Spec: v1alpha1.TestSuiteSpec{
+				Count: 1,
+			},
+			Status: v1alpha1.TestSuiteStatus{
+				Results: []v1alpha1.TestResult{
+					{
+						Name:      "backup",
+						Namespace: "default",
+						DependsOn: []v1alpha1.TestDefReference{{Name: "install", Namespace: "default"}},
+					},
+					{
+						Name:       "install",
+						Namespace:  "default",
+						Status:     depStatus,
+						Executions: []v1alpha1.TestExecution{{ID: "id-111"}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("ignore tests with dependencies that have not succeeded yet", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(v1alpha1.TestRunning)
+		// WHEN & THEN
+		assert.Nil(t, sut.GetTestToRunConcurrently(&suite))
+	})
+
+	t.Run("return test when all dependencies succeeded", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(v1alpha1.TestSucceeded)
+		// WHEN
+		actual := sut.GetTestToRunConcurrently(&suite)
+		// THEN
+		require.NotNil(t, actual)
+		assert.Equal(t, "backup", actual.Name)
+	})
+}
+
+func TestRepeatStrategyWithConcurrencyGroups(t *testing.T) {
+	sut := repeatStrategy{}
+	givenSuite := func(runningGroup string) v1alpha1.ClusterTestSuite {
+		return v1alpha1.ClusterTestSuite{
+			Spec: v1alpha1.TestSuiteSpec{
+				Count: 1,
+			},
+			Status: v1alpha1.TestSuiteStatus{
+				Results: []v1alpha1.TestResult{
+					{
+						Name:             "test-running",
+						Namespace:        "default",
+						ConcurrencyGroup: runningGroup,
+						Executions:       []v1alpha1.TestExecution{{ID: "id-111", PodPhase: v1.PodRunning}},
+					},
+					{
+						Name:             "test-waiting",
+						Namespace:        "other",
+						ConcurrencyGroup: "database",
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("ignore tests from the group of a running test", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("database")
+		// WHEN & THEN
+		assert.Nil(t, sut.GetTestToRunConcurrently(&suite))
+	})
+
+	t.Run("ignore tests from the group of a test that was just scheduled", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("database")
+		suite.Status.Results[0].Executions = []v1alpha1.TestExecution{{ID: "id-111"}}
+		// WHEN & THEN
+		assert.Nil(t, sut.GetTestToRunConcurrently(&suite))
+	})
+
+	t.Run("return test when running test is from other group", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("monitoring")
+		// WHEN
+		actual := sut.GetTestToRunConcurrently(&suite)
+		// THEN
+		require.NotNil(t, actual)
+		assert.Equal(t, "test-waiting", actual.Name)
+	})
+}
@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+)
+
+// getResourcesInUse sums requests of testing pods that are in progress and returns the number of such pods.
+// Requests are calculated from TestDefinitions.
+func (s *Service) getResourcesInUse(suite v1alpha1.GenericTestSuite) (v1.ResourceList, int, error) {
+	inUse := v1.ResourceList{}
+	total := 0
+	for _, tr := range suite.GetStatus().Results {
+		inProgress := 0
+		for _, ex := range tr.Executions {
+			if isInProgress(ex) {
+				inProgress++
+			}
+		}
+		if inProgress == 0 {
+			continue
+		}
+		def, err := s.getDefinition(tr.Name, tr.Namespace)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "while calculating resources in use")
+		}
+		requests := podRequests(def.Spec.Template.Spec)
+		for i := 0; i < inProgress; i++ {
+			addResources(inUse, requests)
+		}
+		total += inProgress
+	}
+	return inUse, total, nil
+}
+
+// podRequests returns requests of a pod the same way as the Kubernetes scheduler calculates them:
+// the sum of requests of all containers, or the highest request of an init container if it is bigger.
+func podRequests(spec v1.PodSpec) v1.ResourceList {
+	out := v1.ResourceList{}
+	for _, c := range spec.Containers {
+		addResources(out, c.Resources.Requests)
+	}
+	for _, c := range spec.InitContainers {
+		for name, q := range c.Resources.Requests {
+			if curr, ok := out[name]; !ok || q.Cmp(curr) > 0 {
+				out[name] = q.DeepCopy()
+			}
+		}
+	}
+	return out
+}
+
+func addResources(dst, src v1.ResourceList) {
+	for name, q := range src {
+		curr := dst[name]
+		curr.Add(q)
+		dst[name] = curr
+	}
+}
+
+// fitsBudget returns true if requests added to resources in use do not exceed any resource defined in the budget
+func fitsBudget(budget, inUse, requests v1.ResourceList) bool {
+	for name, limit := range budget {
+		total := inUse[name].DeepCopy()
+		total.Add(requests[name])
+		if total.Cmp(limit) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// getTestWithinBudget returns the next test to run concurrently that fits into the resource budget of the suite.
+// Tests that do not fit are passed over, so smaller tests can run until there is enough room for bigger ones.
+// Any test fits if nothing is running, otherwise a test that requests more than the budget would never be executed.
+func (s *Service) getTestWithinBudget(suite v1alpha1.GenericTestSuite, strategy nextTestSelectorStrategy) (*v1alpha1.TestResult, error) {
+	budget := suite.GetSpec().ResourceBudget
+	if len(budget) == 0 {
+		return strategy.GetTestToRunConcurrently(suite), nil
+	}
+
+	inUse, inProgress, err := s.getResourcesInUse(suite)
+	if err != nil {
+		return nil, err
+	}
+	if inProgress == 0 {
+		return strategy.GetTestToRunConcurrently(suite), nil
+	}
+	candidates := suite.Copy()
+	for {
+		tr := strategy.GetTestToRunConcurrently(candidates)
+		if tr == nil {
+			return nil, nil
+		}
+		def, err := s.getDefinition(tr.Name, tr.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if fitsBudget(budget, inUse, podRequests(def.Spec.Template.Spec)) {
+			return tr, nil
+		}
+		s.log.Info("Test does not fit into the resource budget", "suite", suite.GetName(), "test", tr.Name, "namespace", tr.Namespace, "variant", tr.Variant, "inUse", inUse, "budget", budget)
+		removeResult(candidates.GetStatus(), *tr)
+	}
+}
+
+func removeResult(status *v1alpha1.TestSuiteStatus, removed v1alpha1.TestResult) {
+	out := make([]v1alpha1.TestResult, 0, len(status.Results))
+	for _, tr := range status.Results {
+		if !tr.IsVariantOf(removed.Name, removed.Namespace, removed.Variant) {
+			out = append(out, tr)
+		}
+	}
+	status.Results = out
+}
@@ -7,24 +7,24 @@ import (
 
 type retryStrategy struct{}
 
-func (r *retryStrategy) GetTestToRunConcurrently(suite v1alpha1.ClusterTestSuite) *v1alpha1.TestResult {
+func (r *retryStrategy) GetTestToRunConcurrently(suite v1alpha1.GenericTestSuite) *v1alpha1.TestResult {
 	return r.getTest(suite, func(tr v1alpha1.TestResult) bool {
 		return tr.DisabledConcurrency == false
 	})
 }
 
-func (r *retryStrategy) GetTestToRunSequentially(suite v1alpha1.ClusterTestSuite) *v1alpha1.TestResult {
+func (r *retryStrategy) GetTestToRunSequentially(suite v1alpha1.GenericTestSuite) *v1alpha1.TestResult {
 	return r.getTest(suite, func(tr v1alpha1.TestResult) bool {
 		return tr.DisabledConcurrency
 	})
 }
 
-func (r *retryStrategy) getTest(suite v1alpha1.ClusterTestSuite, match func(tr v1alpha1.TestResult) bool) *v1alpha1.TestResult {
-	for _, tr := range suite.Status.Results {
-		if !match(tr) {
+func (r *retryStrategy) getTest(suite v1alpha1.GenericTestSuite, match func(tr v1alpha1.TestResult) bool) *v1alpha1.TestResult {
+	for _, tr := range suite.GetStatus().Results {
+		if !match(tr) || tr.Status == v1alpha1.TestSkipped || !dependenciesSucceeded(suite, tr) || isConcurrencyGroupBusy(suite, tr) {
 			continue
 		}
-		if len(tr.Executions) > int(suite.Spec.MaxRetries) {
+		if len(tr.Executions) > int(suite.GetSpec().MaxRetries) {
 			continue
 		}
 
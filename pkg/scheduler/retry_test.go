@@ -11,7 +11,7 @@ import (
 
 func TestRetryStrategy(t *testing.T) {
 	type retryTestCtx struct {
-		testedMethod        func(suite v1alpha1.ClusterTestSuite) *v1alpha1.TestResult
+		testedMethod        func(suite v1alpha1.GenericTestSuite) *v1alpha1.TestResult
 		disabledConcurrency bool
 		testNamePrefix      string
 	}
@@ -35,7 +35,7 @@ func TestRetryStrategy(t *testing.T) {
 			// GIVEN
 			suite := v1alpha1.ClusterTestSuite{}
 			// WHEN
-			actual := tc.testedMethod(suite)
+			actual := tc.testedMethod(&suite)
 			// THEN
 			require.Nil(t, actual)
 		})
@@ -58,7 +58,7 @@ func TestRetryStrategy(t *testing.T) {
 				},
 			}
 			// WHEN
-			actual := tc.testedMethod(suite)
+			actual := tc.testedMethod(&suite)
 			// THEN
 			require.Nil(t, actual)
 		})
@@ -77,7 +77,7 @@ func TestRetryStrategy(t *testing.T) {
 				},
 			}
 			// WHEN
-			actual := tc.testedMethod(suite)
+			actual := tc.testedMethod(&suite)
 			// THEN
 			require.NotNil(t, actual)
 			assert.Equal(t, "test-a", actual.Name)
@@ -98,7 +98,7 @@ func TestRetryStrategy(t *testing.T) {
 				},
 			}
 			// WHEN
-			actual := tc.testedMethod(suite)
+			actual := tc.testedMethod(&suite)
 			// THEN
 			require.NotNil(t, actual)
 			assert.Equal(t, "test-a", actual.Name)
@@ -120,12 +120,32 @@ func TestRetryStrategy(t *testing.T) {
 				},
 			}
 			// WHEN
-			actual := tc.testedMethod(suite)
+			actual := tc.testedMethod(&suite)
 			// THEN
 			require.Nil(t, actual)
 
 		})
 
+		t.Run(fmt.Sprintf("%s ignores skipped tests", tc.testNamePrefix), func(t *testing.T) {
+			// GIVEN
+			suite := v1alpha1.ClusterTestSuite{
+				Spec: specWithRetries(3),
+				Status: v1alpha1.TestSuiteStatus{
+					Results: []v1alpha1.TestResult{
+						{
+							Name:                "test-a",
+							DisabledConcurrency: tc.disabledConcurrency,
+							Status:              v1alpha1.TestSkipped,
+						},
+					},
+				},
+			}
+			// WHEN
+			actual := tc.testedMethod(&suite)
+			// THEN
+			require.Nil(t, actual)
+		})
+
 		t.Run(fmt.Sprintf("%s ignores tests that are currently running", tc.testNamePrefix), func(t *testing.T) {
 			// GIVEN
 			suite := v1alpha1.ClusterTestSuite{
@@ -141,7 +161,7 @@ func TestRetryStrategy(t *testing.T) {
 				},
 			}
 			// WHEN
-			actual := tc.testedMethod(suite)
+			actual := tc.testedMethod(&suite)
 			// THEN
 			require.Nil(t, actual)
 		})
@@ -161,7 +181,7 @@ func TestRetryStrategy(t *testing.T) {
 				},
 			}
 			// WHEN
-			actual := tc.testedMethod(suite)
+			actual := tc.testedMethod(&suite)
 			// THEN
 			require.Nil(t, actual)
 		})
@@ -181,7 +201,7 @@ func TestRetryStrategy(t *testing.T) {
 				},
 			}
 			// WHEN
-			actual := tc.testedMethod(suite)
+			actual := tc.testedMethod(&suite)
 			// THEN
 			require.Nil(t, actual)
 
@@ -209,3 +229,87 @@ func executionsWithPhases(phases ...v1.PodPhase) []v1alpha1.TestExecution {
 	}
 	return out
 }
+
+func TestRetryStrategyWithDependencies(t *testing.T) {
+	sut := retryStrategy{}
+	givenSuite := func(depStatus v1alpha1.TestStatus) v1alpha1.ClusterTestSuite {
+		return v1alpha1.ClusterTestSuite{
+			Spec: specWithRetries(1),
+			Status: v1alpha1.TestSuiteStatus{
+				Results: []v1alpha1.TestResult{
+					{
+						Name:      "backup",
+						Namespace: "default",
+						DependsOn: []v1alpha1.TestDefReference{{Name: "install", Namespace: "default"}},
+					},
+					{
+						Name:       "install",
+						Namespace:  "default",
+						Status:     depStatus,
+						Executions: executionsWithPhases(v1.PodFailed, v1.PodRunning),
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("ignore tests with dependencies that have not succeeded yet", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(v1alpha1.TestRunning)
+		// WHEN & THEN
+		assert.Nil(t, sut.GetTestToRunConcurrently(&suite))
+	})
+
+	t.Run("return test when all dependencies succeeded", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(v1alpha1.TestSucceeded)
+		// WHEN
+		actual := sut.GetTestToRunConcurrently(&suite)
+		// THEN
+		require.NotNil(t, actual)
+		assert.Equal(t, "backup", actual.Name)
+	})
+
+	t.Run("return test when dependency is flaky but finally passed", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(v1alpha1.TestFlaky)
+		// WHEN
+		actual := sut.GetTestToRunConcurrently(&suite)
+		// THEN
+		require.NotNil(t, actual)
+		assert.Equal(t, "backup", actual.Name)
+	})
+}
+
+func TestRetryStrategyWithConcurrencyGroups(t *testing.T) {
+	// GIVEN
+	sut := retryStrategy{}
+	suite := v1alpha1.ClusterTestSuite{
+		Spec: specWithRetries(1),
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				{
+					Name:             "test-retried",
+					Namespace:        "default",
+					ConcurrencyGroup: "database",
+					Executions:       executionsWithPhases(v1.PodFailed),
+				},
+				{
+					Name:             "test-running",
+					Namespace:        "default",
+					ConcurrencyGroup: "database",
+					Executions:       executionsWithPhases(v1.PodPending),
+				},
+				{
+					Name:      "test-without-group",
+					Namespace: "default",
+				},
+			},
+		},
+	}
+	// WHEN
+	actual := sut.GetTestToRunConcurrently(&suite)
+	// THEN
+	require.NotNil(t, actual)
+	assert.Equal(t, "test-without-group", actual.Name)
+}
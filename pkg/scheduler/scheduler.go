@@ -3,8 +3,10 @@ package scheduler
 import (
 	"context"
 	"fmt"
+
 	"github.com/go-logr/logr"
 	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/events"
 	"github.com/pkg/errors"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,26 +16,31 @@ import (
 )
 
 type StatusProvider interface {
-	MarkAsScheduled(status v1alpha1.TestSuiteStatus, testName, testNs, podName string) (v1alpha1.TestSuiteStatus, error)
-	GetExecutionsInProgress(suite v1alpha1.ClusterTestSuite) []v1alpha1.TestExecution
+	MarkAsScheduled(status v1alpha1.TestSuiteStatus, testName, testNs, variant, podName string) (v1alpha1.TestSuiteStatus, error)
+	GetExecutionsInProgress(suite v1alpha1.GenericTestSuite) []v1alpha1.TestExecution
 }
 
 // nextTestSelectorStrategy
 type nextTestSelectorStrategy interface {
-	GetTestToRunConcurrently(suite v1alpha1.ClusterTestSuite) *v1alpha1.TestResult
-	GetTestToRunSequentially(suite v1alpha1.ClusterTestSuite) *v1alpha1.TestResult
+	GetTestToRunConcurrently(suite v1alpha1.GenericTestSuite) *v1alpha1.TestResult
+	GetTestToRunSequentially(suite v1alpha1.GenericTestSuite) *v1alpha1.TestResult
+}
+
+type EventRecorder interface {
+	SuiteEvent(suite v1alpha1.GenericTestSuite, eventType, reason, messageFmt string, args ...interface{})
 }
 
 type podNameProvider interface {
-	GetName(suite v1alpha1.ClusterTestSuite, def v1alpha1.TestDefinition) (string, error)
+	GetName(suite v1alpha1.GenericTestSuite, def v1alpha1.TestDefinition, variant string) (string, error)
 }
 
-func NewService(statusProvider StatusProvider, reader client.Reader, writer client.Writer, scheme *runtime.Scheme, logger logr.Logger) *Service {
+func NewService(statusProvider StatusProvider, reader client.Reader, writer client.Writer, scheme *runtime.Scheme, recorder EventRecorder, logger logr.Logger) *Service {
 	return &Service{
 		statusProvider: statusProvider,
 		reader:         reader,
 		writer:         writer,
 		scheme:         scheme,
+		recorder:       recorder,
 		log:            logger,
 	}
 }
@@ -43,10 +50,11 @@ type Service struct {
 	reader         client.Reader
 	writer         client.Writer
 	scheme         *runtime.Scheme
+	recorder       EventRecorder
 	log            logr.Logger
 }
 
-func (s *Service) TrySchedule(suite v1alpha1.ClusterTestSuite) (*v1.Pod, *v1alpha1.TestSuiteStatus, error) {
+func (s *Service) TrySchedule(suite v1alpha1.GenericTestSuite) (*v1.Pod, *v1alpha1.TestSuiteStatus, error) {
 	tr, err := s.getNextToSchedule(suite)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "while getting next to schedule")
@@ -58,18 +66,32 @@ func (s *Service) TrySchedule(suite v1alpha1.ClusterTestSuite) (*v1.Pod, *v1alph
 	if err != nil {
 		return nil, nil, err
 	}
-	pod, err := s.startPod(suite, def)
+	pod, err := s.startPod(suite, def, *tr)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	curr, err := s.statusProvider.MarkAsScheduled(suite.Status, tr.Name, tr.Namespace, pod.Name)
+	curr, err := s.statusProvider.MarkAsScheduled(*suite.GetStatus(), tr.Name, tr.Namespace, tr.Variant, pod.Name)
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "while marking suite [%s] as Scheduled", suite.Name)
+		return nil, nil, errors.Wrapf(err, "while marking suite [%s] as Scheduled", suite.GetName())
 	}
+	s.recordScheduled(suite, *tr, def, pod.Name)
 	return pod, &curr, nil
 }
 
+// recordScheduled records an Event about a new testing pod. The pod is a retry if retries are enabled and the previous execution of the test failed.
+func (s *Service) recordScheduled(suite v1alpha1.GenericTestSuite, tr v1alpha1.TestResult, def v1alpha1.TestDefinition, podName string) {
+	if hook := s.getHookKind(suite, def); hook != "" {
+		s.recorder.SuiteEvent(suite, v1.EventTypeNormal, events.ReasonScheduled, "Testing pod [%s] created for %s [name: %s, namespace: %s]", podName, hook, tr.Name, tr.Namespace)
+		return
+	}
+	if n := len(tr.Executions); suite.GetSpec().MaxRetries > 0 && n > 0 && tr.Executions[n-1].PodPhase == v1.PodFailed {
+		s.recorder.SuiteEvent(suite, v1.EventTypeNormal, events.ReasonRetrying, "Testing pod [%s] created to retry failed test [name: %s, namespace: %s]", podName, tr.Name, tr.Namespace)
+		return
+	}
+	s.recorder.SuiteEvent(suite, v1.EventTypeNormal, events.ReasonScheduled, "Testing pod [%s] created for test [name: %s, namespace: %s]", podName, tr.Name, tr.Namespace)
+}
+
 func (s *Service) getDefinition(name, ns string) (v1alpha1.TestDefinition, error) {
 	var out v1alpha1.TestDefinition
 	err := s.reader.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: ns}, &out)
@@ -79,24 +101,43 @@ func (s *Service) getDefinition(name, ns string) (v1alpha1.TestDefinition, error
 	return out, nil
 }
 
-func (s *Service) getNextToSchedule(suite v1alpha1.ClusterTestSuite) (*v1alpha1.TestResult, error) {
-	suite = s.normalizeSuite(suite)
+func (s *Service) getNextToSchedule(suite v1alpha1.GenericTestSuite) (*v1alpha1.TestResult, error) {
 	running := s.statusProvider.GetExecutionsInProgress(suite)
+	suite = s.normalizeSuite(suite)
 
-	logSuite := s.log.WithValues("suite", suite.Name)
-	if len(running) >= int(suite.Spec.Concurrency) {
-		logSuite.Info("Cannot get next test to schedule, max concurrency reached", "running", len(running), "concurrency", suite.Spec.Concurrency)
+	logSuite := s.log.WithValues("suite", suite.GetName())
+	if suite.GetSpec().Paused {
+		// executions in progress are finished, but no new testing pods are created until the suite is resumed
+		logSuite.Info("Cannot get next test to schedule, suite is paused")
+		return nil, nil
+	}
+	if hook, wait := s.getHookToSchedule(suite); wait {
+		if hook == nil {
+			logSuite.Info("Cannot get next test to schedule, waiting for setup or teardown")
+		}
+		return hook, nil
+	}
+	if s.isStopped(suite) {
+		logSuite.Info("Cannot get next test to schedule, suite stopped after too many failed tests")
+		return nil, nil
+	}
+	if suite.GetSpec().Concurrency > 0 && len(running) >= int(suite.GetSpec().Concurrency) {
+		logSuite.Info("Cannot get next test to schedule, max concurrency reached", "running", len(running), "concurrency", suite.GetSpec().Concurrency)
 		return nil, nil
 	}
 
 	strategy := s.getStrategyForSuite(suite)
 	if strategy == nil {
-		err := fmt.Errorf("cannot find test selector strategy that is applicable for suite [%s]", suite.Name)
+		err := fmt.Errorf("cannot find test selector strategy that is applicable for suite [%s]", suite.GetName())
 		logSuite.Error(err, "No applicable strategy")
 		return nil, err
 	}
 
-	if toRunCandidate := strategy.GetTestToRunConcurrently(suite); toRunCandidate != nil {
+	toRunCandidate, err := s.getTestWithinBudget(suite, strategy)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting test to run concurrently")
+	}
+	if toRunCandidate != nil {
 		return toRunCandidate, nil
 	}
 
@@ -110,19 +151,25 @@ func (s *Service) getNextToSchedule(suite v1alpha1.ClusterTestSuite) (*v1alpha1.
 	return nil, nil
 }
 
-// TODO this is only workaround, proper implementation will be done here: https://github.com/kyma-incubator/octopus/issues/11
-func (s *Service) normalizeSuite(suite v1alpha1.ClusterTestSuite) v1alpha1.ClusterTestSuite {
-	if suite.Spec.Concurrency == 0 {
-		suite.Spec.Concurrency = 1
-	}
-	if suite.Spec.Count == 0 {
-		suite.Spec.Count = 1
+// isStopped returns true if the suite reached the failure threshold and no more tests should be started
+func (s *Service) isStopped(suite v1alpha1.GenericTestSuite) bool {
+	for _, cond := range suite.GetStatus().Conditions {
+		if cond.Status == v1alpha1.StatusTrue && cond.Reason == v1alpha1.ReasonFailureThresholdReached {
+			return true
+		}
 	}
-	return suite
+	return false
 }
 
-func (s *Service) getStrategyForSuite(suite v1alpha1.ClusterTestSuite) nextTestSelectorStrategy {
-	if suite.Spec.MaxRetries == 0 {
+// normalizeSuite sets default values on a suite, which are not persisted if the defaulting webhook is disabled.
+func (s *Service) normalizeSuite(suite v1alpha1.GenericTestSuite) v1alpha1.GenericTestSuite {
+	normalized := suite.Copy()
+	normalized.GetSpec().SetDefaults()
+	return normalized
+}
+
+func (s *Service) getStrategyForSuite(suite v1alpha1.GenericTestSuite) nextTestSelectorStrategy {
+	if suite.GetSpec().MaxRetries == 0 {
 		return &repeatStrategy{}
 	} else {
 		return &retryStrategy{}
@@ -134,14 +181,18 @@ func (s *Service) getNameProvider() podNameProvider {
 	return &PodNameGenerator{}
 }
 
-func (s *Service) startPod(suite v1alpha1.ClusterTestSuite, def v1alpha1.TestDefinition) (*v1.Pod, error) {
+// startPod creates a testing pod from the TestDefinition template. Environment and volumes from the suite are merged
+// into the template, and then parameters of the variant are injected as environment variables into all containers.
+func (s *Service) startPod(suite v1alpha1.GenericTestSuite, def v1alpha1.TestDefinition, tr v1alpha1.TestResult) (*v1.Pod, error) {
 	p := &v1.Pod{}
 	// TODO (aszeowka)(later) https://github.com/kubernetes/kubernetes/blob/master/pkg/controller/controller_utils.go#L517-L522
-	p.Spec = def.Spec.Template.Spec
+	p.Spec = *def.Spec.Template.Spec.DeepCopy()
 	p.Labels = def.Spec.Template.Labels
 	p.Annotations = def.Spec.Template.Annotations
+	injectSuiteSpec(&p.Spec, *suite.GetSpec())
+	injectParameters(&p.Spec, tr.Parameters)
 
-	name, err := s.getNameProvider().GetName(suite, def)
+	name, err := s.getNameProvider().GetName(suite, def, tr.Variant)
 	if err != nil {
 		return nil, err
 	}
@@ -151,18 +202,21 @@ func (s *Service) startPod(suite v1alpha1.ClusterTestSuite, def v1alpha1.TestDef
 	if p.Labels == nil {
 		p.Labels = make(map[string]string)
 	}
-	p.Labels[v1alpha1.LabelKeySuiteName] = suite.Name
+	p.Labels[v1alpha1.LabelKeySuiteName] = suite.GetName()
 	p.Labels[v1alpha1.LabelKeyTestDefName] = def.Name
 	p.Labels[v1alpha1.LabelKeyCreatedByOctopus] = "true"
+	if tr.Variant != "" {
+		p.Labels[v1alpha1.LabelKeyVariant] = tr.Variant
+	}
 	p.Spec.RestartPolicy = v1.RestartPolicyNever
 
-	if err := controllerutil.SetControllerReference(&suite, p, s.scheme); err != nil {
-		return nil, errors.Wrapf(err, "while setting controller reference, suite [%s], pod [name %s, namespace: %s]", suite.Name, p.Name, p.Namespace)
+	if err := controllerutil.SetControllerReference(suite, p, s.scheme); err != nil {
+		return nil, errors.Wrapf(err, "while setting controller reference, suite [%s], pod [name %s, namespace: %s]", suite.GetName(), p.Name, p.Namespace)
 	}
 
 	err = s.writer.Create(context.TODO(), p)
 	if err != nil {
-		return nil, errors.Wrapf(err, "while creating testing pod for suite [%s] and test definition [name: %s, namespace: %s]", suite.Name, def.Name, def.Namespace)
+		return nil, errors.Wrapf(err, "while creating testing pod for suite [%s] and test definition [name: %s, namespace: %s]", suite.GetName(), def.Name, def.Namespace)
 	}
 	return p, nil
 }
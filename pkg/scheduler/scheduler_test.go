@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	v12 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -32,17 +33,21 @@ func TestTryScheduleHappyPath(t *testing.T) {
 
 	mockStatusProvider := &automock.StatusProvider{}
 	defer mockStatusProvider.AssertExpectations(t)
-	mockStatusProvider.On("GetExecutionsInProgress", uninitializedSuite).Return(nil).Once()
-	mockStatusProvider.On("MarkAsScheduled", uninitializedSuite.Status, "test-name", "test-namespace", mock.Anything).Return(scheduledSuite.Status, nil)
+	mockStatusProvider.On("GetExecutionsInProgress", &uninitializedSuite).Return(nil).Once()
+	mockStatusProvider.On("MarkAsScheduled", uninitializedSuite.Status, "test-name", "test-namespace", "", mock.Anything).Return(scheduledSuite.Status, nil)
 
 	fakeCli, sch, err := getFakeClient(&givenTd)
 
 	require.NoError(t, err)
 
-	sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, rlog.Log)
+	mockRecorder := &automock.EventRecorder{}
+	defer mockRecorder.AssertExpectations(t)
+	mockRecorder.On("SuiteEvent", &uninitializedSuite, v12.EventTypeNormal, "Scheduled", "Testing pod [%s] created for test [name: %s, namespace: %s]", "oct-tp-test-all-test-name-0", "test-name", "test-namespace").Once()
+
+	sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, mockRecorder, rlog.Log)
 
 	// WHEN
-	pod, status, err := sut.TrySchedule(uninitializedSuite)
+	pod, status, err := sut.TrySchedule(&uninitializedSuite)
 	// THEN
 	require.NoError(t, err)
 	assert.NotNil(t, pod)
@@ -88,10 +93,57 @@ func TestTryScheduleNoTestToExecuteNow(t *testing.T) {
 			Count:       1,
 		},
 	}
-	mockStatusProvider.On("GetExecutionsInProgress", suite).Return(nil)
-	sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, mockLogger)
+	mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
+	sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, mockLogger)
+	// WHEN
+	actualPod, actualStatus, err := sut.TrySchedule(&suite)
+	// THEN
+	require.NoError(t, err)
+	assert.Nil(t, actualPod)
+	assert.Nil(t, actualStatus)
+}
+
+func TestTryScheduleSuiteStoppedAfterFailures(t *testing.T) {
+	// GIVEN
+	mockStatusProvider := &automock.StatusProvider{}
+	defer mockStatusProvider.AssertExpectations(t)
+
+	mockLogger := &automock.Logger{}
+	defer mockLogger.AssertExpectations(t)
+	mockLogger.ExpectLoggedWithValues("suite", "test-all")
+	mockLogger.ExpectLoggedOnInfo("Cannot get next test to schedule, suite stopped after too many failed tests")
+
+	suite := givenUninitializedSuite(givenTestResult())
+	suite.Spec.FailFast = true
+	suite.Status.Conditions = []v1alpha1.TestSuiteCondition{
+		{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusTrue, Reason: v1alpha1.ReasonFailureThresholdReached},
+	}
+	mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
+	sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, mockLogger)
 	// WHEN
-	actualPod, actualStatus, err := sut.TrySchedule(suite)
+	actualPod, actualStatus, err := sut.TrySchedule(&suite)
+	// THEN
+	require.NoError(t, err)
+	assert.Nil(t, actualPod)
+	assert.Nil(t, actualStatus)
+}
+
+func TestTrySchedulePausedSuite(t *testing.T) {
+	// GIVEN
+	mockStatusProvider := &automock.StatusProvider{}
+	defer mockStatusProvider.AssertExpectations(t)
+
+	mockLogger := &automock.Logger{}
+	defer mockLogger.AssertExpectations(t)
+	mockLogger.ExpectLoggedWithValues("suite", "test-all")
+	mockLogger.ExpectLoggedOnInfo("Cannot get next test to schedule, suite is paused")
+
+	suite := givenUninitializedSuite(givenTestResult())
+	suite.Spec.Paused = true
+	mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
+	sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, mockLogger)
+	// WHEN
+	actualPod, actualStatus, err := sut.TrySchedule(&suite)
 	// THEN
 	require.NoError(t, err)
 	assert.Nil(t, actualPod)
@@ -114,9 +166,9 @@ func TestTryScheduleErrorOnGettingTestDef(t *testing.T) {
 	fakeCli, sch, err := getFakeClient()
 	require.NoError(t, err)
 
-	sut := scheduler.NewService(mockStatusProvider, fakeCli, nil, sch, mockLogger)
+	sut := scheduler.NewService(mockStatusProvider, fakeCli, nil, sch, nil, mockLogger)
 	// WHEN
-	_, _, err = sut.TrySchedule(uninitializedSuite)
+	_, _, err = sut.TrySchedule(&uninitializedSuite)
 	// THEN
 	require.EqualError(t, err, "while getting test definition [name: test-name, namespace: test-namespace]: testdefinitions.testing.kyma-project.io \"test-name\" not found")
 }
@@ -133,7 +185,7 @@ func TestTryScheduleErrorOnSchedulingPod(t *testing.T) {
 
 	mockStatusProvider := &automock.StatusProvider{}
 	defer mockStatusProvider.AssertExpectations(t)
-	mockStatusProvider.On("GetExecutionsInProgress", uninitializedSuite).Return(nil).Once()
+	mockStatusProvider.On("GetExecutionsInProgress", &uninitializedSuite).Return(nil).Once()
 	// TODO (aszecowka): later we should mark somehow on test suite, that error occurred
 
 	fakeCli, sch, err := getFakeClient(&givenTd)
@@ -142,10 +194,10 @@ func TestTryScheduleErrorOnSchedulingPod(t *testing.T) {
 	defer mockWriter.AssertExpectations(t)
 	mockWriter.On("Create", mock.Anything, mock.Anything).Return(errors.New("some error"))
 
-	sut := scheduler.NewService(mockStatusProvider, fakeCli, mockWriter, sch, rlog.Log)
+	sut := scheduler.NewService(mockStatusProvider, fakeCli, mockWriter, sch, nil, rlog.Log)
 
 	// WHEN
-	_, _, err = sut.TrySchedule(uninitializedSuite)
+	_, _, err = sut.TrySchedule(&uninitializedSuite)
 	// THEN
 	assert.EqualError(t, err, "while creating testing pod for suite [test-all] and test definition [name: test-name, namespace: test-namespace]: some error")
 }
@@ -162,16 +214,16 @@ func TestTryScheduleErrorOnUpdatingStatus(t *testing.T) {
 
 	mockStatusProvider := &automock.StatusProvider{}
 	defer mockStatusProvider.AssertExpectations(t)
-	mockStatusProvider.On("GetExecutionsInProgress", uninitializedSuite).Return(nil).Once()
-	mockStatusProvider.On("MarkAsScheduled", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(v1alpha1.TestSuiteStatus{}, errors.New("some error"))
+	mockStatusProvider.On("GetExecutionsInProgress", &uninitializedSuite).Return(nil).Once()
+	mockStatusProvider.On("MarkAsScheduled", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(v1alpha1.TestSuiteStatus{}, errors.New("some error"))
 
 	fakeCli, sch, err := getFakeClient(&givenTd)
 	require.NoError(t, err)
 
-	sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, rlog.Log)
+	sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, nil, rlog.Log)
 
 	// WHEN
-	_, _, err = sut.TrySchedule(uninitializedSuite)
+	_, _, err = sut.TrySchedule(&uninitializedSuite)
 	// THEN
 	require.EqualError(t, err, "while marking suite [test-all] as Scheduled: some error")
 }
@@ -189,7 +241,7 @@ func TestGetNextToSchedule(t *testing.T) {
 			},
 		}
 		mockStatusProvider := &automock.StatusProvider{}
-		mockStatusProvider.On("GetExecutionsInProgress", suite).Return([]v1alpha1.TestExecution{
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return([]v1alpha1.TestExecution{
 			{ID: "id-111"}, {ID: "id-222"}, {ID: "id-333"},
 		})
 
@@ -198,7 +250,7 @@ func TestGetNextToSchedule(t *testing.T) {
 		mockLogger.ExpectLoggedOnInfo("Cannot get next test to schedule, max concurrency reached", "running", 3, "concurrency", int64(3))
 		defer mockLogger.AssertExpectations(t)
 		defer mockStatusProvider.AssertExpectations(t)
-		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, mockLogger)
+		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, mockLogger)
 
 		// WHEN
 		actual, err := sut.GetNextToSchedule(suite)
@@ -215,7 +267,7 @@ func TestGetNextToSchedule(t *testing.T) {
 		mockLogger := &automock.Logger{}
 		mockLogger.ExpectLoggedWithValues("suite", "test-all")
 		defer mockLogger.AssertExpectations(t)
-		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, mockLogger)
+		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, mockLogger)
 		// WHEN
 		suite := v1alpha1.ClusterTestSuite{
 			ObjectMeta: v1.ObjectMeta{
@@ -300,10 +352,10 @@ func TestGetNextToSchedule(t *testing.T) {
 		mockLogger := &automock.Logger{}
 		defer mockLogger.AssertExpectations(t)
 
-		mockStatusProvider.On("GetExecutionsInProgress", suite).Return(nil)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
 		mockLogger.ExpectLoggedWithValues("suite", "test-all")
 
-		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, mockLogger)
+		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, mockLogger)
 		// WHEN
 		actual, err := sut.GetNextToSchedule(suite)
 		// THEN
@@ -364,7 +416,7 @@ func TestGetNextToSchedule(t *testing.T) {
 			},
 		}
 
-		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, mockLogger)
+		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, mockLogger)
 		// WHEN
 		actual, err := sut.GetNextToSchedule(suite)
 		// THEN
@@ -374,6 +426,388 @@ func TestGetNextToSchedule(t *testing.T) {
 
 }
 
+func TestGetNextToScheduleWithResourceBudget(t *testing.T) {
+	givenSuite := func(results ...v1alpha1.TestResult) v1alpha1.ClusterTestSuite {
+		return v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "test-all",
+			},
+			Spec: v1alpha1.TestSuiteSpec{
+				Count: 1,
+				ResourceBudget: v12.ResourceList{
+					v12.ResourceCPU:    resource.MustParse("2"),
+					v12.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+			Status: v1alpha1.TestSuiteStatus{
+				Results: results,
+			},
+		}
+	}
+	running := v1alpha1.TestResult{
+		Name:       "test-running",
+		Namespace:  "test-namespace",
+		Executions: []v1alpha1.TestExecution{{ID: "id-111", PodPhase: v12.PodRunning}},
+	}
+	heavy := v1alpha1.TestResult{Name: "test-heavy", Namespace: "test-namespace"}
+	small := v1alpha1.TestResult{Name: "test-small", Namespace: "test-namespace"}
+
+	t.Run("returns smaller test if the next test does not fit into the budget", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(running, heavy, small)
+		mockStatusProvider := &automock.StatusProvider{}
+		defer mockStatusProvider.AssertExpectations(t)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(running.Executions)
+
+		fakeCli, sch, err := getFakeClient(
+			givenTestDefinitionWithRequests("test-running", "1", "512Mi"),
+			givenTestDefinitionWithRequests("test-heavy", "4", "128Mi"),
+			givenTestDefinitionWithRequests("test-small", "500m", "256Mi"),
+		)
+		require.NoError(t, err)
+		sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, nil, rlog.Log)
+		// WHEN
+		actual, err := sut.GetNextToSchedule(suite)
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, "test-small", actual.Name)
+	})
+
+	t.Run("returns nil if no test fits into the remaining budget", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(running, heavy, small)
+		mockStatusProvider := &automock.StatusProvider{}
+		defer mockStatusProvider.AssertExpectations(t)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(running.Executions)
+
+		fakeCli, sch, err := getFakeClient(
+			givenTestDefinitionWithRequests("test-running", "1", "768Mi"),
+			givenTestDefinitionWithRequests("test-heavy", "4", "128Mi"),
+			givenTestDefinitionWithRequests("test-small", "500m", "512Mi"),
+		)
+		require.NoError(t, err)
+		sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, nil, rlog.Log)
+		// WHEN
+		actual, err := sut.GetNextToSchedule(suite)
+		// THEN
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("counts requests of a test that was just scheduled", func(t *testing.T) {
+		// GIVEN
+		scheduled := v1alpha1.TestResult{
+			Name:       "test-running",
+			Namespace:  "test-namespace",
+			Executions: []v1alpha1.TestExecution{{ID: "id-111"}},
+		}
+		suite := givenSuite(scheduled, heavy, small)
+		mockStatusProvider := &automock.StatusProvider{}
+		defer mockStatusProvider.AssertExpectations(t)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
+
+		fakeCli, sch, err := getFakeClient(
+			givenTestDefinitionWithRequests("test-running", "1", "768Mi"),
+			givenTestDefinitionWithRequests("test-heavy", "4", "128Mi"),
+			givenTestDefinitionWithRequests("test-small", "500m", "512Mi"),
+		)
+		require.NoError(t, err)
+		sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, nil, rlog.Log)
+		// WHEN
+		actual, err := sut.GetNextToSchedule(suite)
+		// THEN
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("returns test exceeding the budget if nothing is running", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(heavy, small)
+		mockStatusProvider := &automock.StatusProvider{}
+		defer mockStatusProvider.AssertExpectations(t)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
+
+		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, rlog.Log)
+		// WHEN
+		actual, err := sut.GetNextToSchedule(suite)
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, "test-heavy", actual.Name)
+	})
+
+	t.Run("returns error if definition of running test cannot be fetched", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(running, small)
+		mockStatusProvider := &automock.StatusProvider{}
+		defer mockStatusProvider.AssertExpectations(t)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(running.Executions)
+
+		fakeCli, sch, err := getFakeClient()
+		require.NoError(t, err)
+		sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, nil, rlog.Log)
+		// WHEN
+		_, err = sut.GetNextToSchedule(suite)
+		// THEN
+		require.EqualError(t, err, "while getting test to run concurrently: while calculating resources in use: while getting test definition [name: test-running, namespace: test-namespace]: testdefinitions.testing.kyma-project.io \"test-running\" not found")
+	})
+}
+
+func TestGetNextToScheduleWithHooks(t *testing.T) {
+	givenSuite := func(cond v1alpha1.TestSuiteConditionType, setup v1alpha1.TestStatus) v1alpha1.ClusterTestSuite {
+		return v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1, Concurrency: 1},
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: []v1alpha1.TestSuiteCondition{{Type: cond, Status: v1alpha1.StatusTrue}},
+				Setup:      &v1alpha1.TestResult{Name: "seed", Namespace: "default", Status: setup},
+				Teardown:   &v1alpha1.TestResult{Name: "cleanup", Namespace: "default", Status: v1alpha1.TestNotYetScheduled},
+				Results:    []v1alpha1.TestResult{{Name: "test-a", Namespace: "default", Status: v1alpha1.TestNotYetScheduled}},
+			},
+		}
+	}
+
+	t.Run("returns setup before tests", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(v1alpha1.SuiteRunning, v1alpha1.TestNotYetScheduled)
+		mockStatusProvider := &automock.StatusProvider{}
+		defer mockStatusProvider.AssertExpectations(t)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
+		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, rlog.Log)
+		// WHEN
+		actual, err := sut.GetNextToSchedule(suite)
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, "seed", actual.Name)
+	})
+
+	t.Run("returns nil while setup is running", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(v1alpha1.SuiteRunning, v1alpha1.TestRunning)
+		mockStatusProvider := &automock.StatusProvider{}
+		defer mockStatusProvider.AssertExpectations(t)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
+		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, rlog.Log)
+		// WHEN
+		actual, err := sut.GetNextToSchedule(suite)
+		// THEN
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+
+	t.Run("returns test when setup succeeded", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(v1alpha1.SuiteRunning, v1alpha1.TestSucceeded)
+		mockStatusProvider := &automock.StatusProvider{}
+		defer mockStatusProvider.AssertExpectations(t)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
+		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, rlog.Log)
+		// WHEN
+		actual, err := sut.GetNextToSchedule(suite)
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, "test-a", actual.Name)
+	})
+
+	t.Run("returns teardown when suite has final condition", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite(v1alpha1.SuiteFailed, v1alpha1.TestFailed)
+		mockStatusProvider := &automock.StatusProvider{}
+		defer mockStatusProvider.AssertExpectations(t)
+		mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil)
+		sut := scheduler.NewService(mockStatusProvider, nil, nil, nil, nil, rlog.Log)
+		// WHEN
+		actual, err := sut.GetNextToSchedule(suite)
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, "cleanup", actual.Name)
+	})
+}
+
+func TestTryScheduleTeardown(t *testing.T) {
+	// GIVEN
+	suite := givenUninitializedSuite(givenTestResult())
+	suite.Spec.Teardown = &v1alpha1.TestDefReference{Name: "test-name", Namespace: "test-namespace"}
+	suite.Status.Conditions[0].Type = v1alpha1.SuiteSucceeded
+	suite.Status.Results = nil
+	suite.Status.Teardown = &v1alpha1.TestResult{Name: "test-name", Namespace: "test-namespace", Status: v1alpha1.TestNotYetScheduled}
+	givenTd := givenTestDefinition()
+
+	mockStatusProvider := &automock.StatusProvider{}
+	defer mockStatusProvider.AssertExpectations(t)
+	mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil).Once()
+	mockStatusProvider.On("MarkAsScheduled", suite.Status, "test-name", "test-namespace", "", "oct-tp-test-all-test-name-0").Return(suite.Status, nil)
+
+	fakeCli, sch, err := getFakeClient(&givenTd)
+	require.NoError(t, err)
+
+	mockRecorder := &automock.EventRecorder{}
+	defer mockRecorder.AssertExpectations(t)
+	mockRecorder.On("SuiteEvent", &suite, v12.EventTypeNormal, "Scheduled", "Testing pod [%s] created for %s [name: %s, namespace: %s]", "oct-tp-test-all-test-name-0", "teardown", "test-name", "test-namespace").Once()
+
+	sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, mockRecorder, rlog.Log)
+	// WHEN
+	pod, _, err := sut.TrySchedule(&suite)
+	// THEN
+	require.NoError(t, err)
+	require.NotNil(t, pod)
+	assert.Equal(t, "oct-tp-test-all-test-name-0", pod.Name)
+}
+
+func TestTryScheduleVariant(t *testing.T) {
+	// GIVEN
+	mysql := givenTestResult()
+	mysql.Variant = "mysql"
+	mysql.Parameters = map[string]string{"DB_HOST": "mysql"}
+	mysql.Executions = []v1alpha1.TestExecution{{ID: "oct-tp-test-all-test-name-mysql-0", PodPhase: v12.PodSucceeded}}
+	postgres := givenTestResult()
+	postgres.Variant = "postgres"
+	postgres.Parameters = map[string]string{"DB_HOST": "postgres", "DB_PORT": "5432"}
+	suite := givenUninitializedSuite(mysql)
+	suite.Status.Conditions[0].Type = v1alpha1.SuiteRunning
+	suite.Status.Results = append(suite.Status.Results, postgres)
+	givenTd := givenTestDefinition()
+	givenTd.Spec.Template.Spec.Containers[0].Env = []v12.EnvVar{{Name: "DB_HOST", Value: "localhost"}, {Name: "DEBUG", Value: "true"}}
+
+	mockStatusProvider := &automock.StatusProvider{}
+	defer mockStatusProvider.AssertExpectations(t)
+	mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil).Once()
+	mockStatusProvider.On("MarkAsScheduled", suite.Status, "test-name", "test-namespace", "postgres", "oct-tp-test-all-test-name-postgres-0").Return(suite.Status, nil)
+
+	fakeCli, sch, err := getFakeClient(&givenTd)
+	require.NoError(t, err)
+
+	mockRecorder := &automock.EventRecorder{}
+	defer mockRecorder.AssertExpectations(t)
+	mockRecorder.On("SuiteEvent", &suite, v12.EventTypeNormal, "Scheduled", "Testing pod [%s] created for test [name: %s, namespace: %s]", "oct-tp-test-all-test-name-postgres-0", "test-name", "test-namespace").Once()
+
+	sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, mockRecorder, rlog.Log)
+	// WHEN
+	pod, _, err := sut.TrySchedule(&suite)
+	// THEN
+	require.NoError(t, err)
+	require.NotNil(t, pod)
+	assert.Equal(t, "oct-tp-test-all-test-name-postgres-0", pod.Name)
+	assert.Equal(t, "postgres", pod.Labels[v1alpha1.LabelKeyVariant])
+	assert.Equal(t, []v12.EnvVar{
+		{Name: "DB_HOST", Value: "postgres"},
+		{Name: "DEBUG", Value: "true"},
+		{Name: "DB_PORT", Value: "5432"},
+	}, pod.Spec.Containers[0].Env)
+}
+
+func TestTryScheduleAfterFailedExecution(t *testing.T) {
+	for name, tc := range map[string]struct {
+		maxRetries int64
+		reason     string
+		message    string
+	}{
+		"retry": {
+			maxRetries: 1,
+			reason:     "Retrying",
+			message:    "Testing pod [%s] created to retry failed test [name: %s, namespace: %s]",
+		},
+		"repeat without retries": {
+			maxRetries: 0,
+			reason:     "Scheduled",
+			message:    "Testing pod [%s] created for test [name: %s, namespace: %s]",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			tr := givenTestResult()
+			tr.Executions = []v1alpha1.TestExecution{{ID: "oct-tp-test-all-test-name-0", PodPhase: v12.PodFailed, CompletionTime: &v1.Time{}}}
+			suite := givenUninitializedSuite(tr)
+			suite.Status.Conditions[0].Type = v1alpha1.SuiteRunning
+			suite.Spec.Count = 2
+			suite.Spec.MaxRetries = tc.maxRetries
+			givenTd := givenTestDefinition()
+
+			mockStatusProvider := &automock.StatusProvider{}
+			defer mockStatusProvider.AssertExpectations(t)
+			mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil).Once()
+			mockStatusProvider.On("MarkAsScheduled", suite.Status, "test-name", "test-namespace", "", "oct-tp-test-all-test-name-1").Return(suite.Status, nil)
+
+			fakeCli, sch, err := getFakeClient(&givenTd)
+			require.NoError(t, err)
+
+			mockRecorder := &automock.EventRecorder{}
+			defer mockRecorder.AssertExpectations(t)
+			mockRecorder.On("SuiteEvent", &suite, v12.EventTypeNormal, tc.reason, tc.message, "oct-tp-test-all-test-name-1", "test-name", "test-namespace").Once()
+
+			sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, mockRecorder, rlog.Log)
+			// WHEN
+			pod, _, err := sut.TrySchedule(&suite)
+			// THEN
+			require.NoError(t, err)
+			require.NotNil(t, pod)
+			assert.Equal(t, "oct-tp-test-all-test-name-1", pod.Name)
+		})
+	}
+}
+
+func TestTryScheduleWithSuiteEnvironment(t *testing.T) {
+	// GIVEN
+	tr := givenTestResult()
+	tr.Variant = "postgres"
+	tr.Parameters = map[string]string{"DB_HOST": "postgres"}
+	suite := givenUninitializedSuite(tr)
+	suite.Spec.Env = []v12.EnvVar{{Name: "CLUSTER_URL", Value: "https://cluster.local"}, {Name: "DB_HOST", Value: "suite"}}
+	suite.Spec.EnvFrom = []v12.EnvFromSource{{SecretRef: &v12.SecretEnvSource{LocalObjectReference: v12.LocalObjectReference{Name: "suite-credentials"}}}}
+	suite.Spec.Volumes = []v12.Volume{{Name: "certs", VolumeSource: v12.VolumeSource{Secret: &v12.SecretVolumeSource{SecretName: "suite-certs"}}}}
+	suite.Spec.VolumeMounts = []v12.VolumeMount{{Name: "certs", MountPath: "/etc/certs"}}
+	givenTd := givenTestDefinition()
+	givenTd.Spec.Template.Spec.InitContainers = []v12.Container{{Image: "busybox"}}
+	givenTd.Spec.Template.Spec.Containers[0].Env = []v12.EnvVar{{Name: "CLUSTER_URL", Value: "http://localhost"}, {Name: "DEBUG", Value: "true"}}
+	givenTd.Spec.Template.Spec.Containers[0].EnvFrom = []v12.EnvFromSource{{ConfigMapRef: &v12.ConfigMapEnvSource{LocalObjectReference: v12.LocalObjectReference{Name: "test-config"}}}}
+	givenTd.Spec.Template.Spec.Containers[0].VolumeMounts = []v12.VolumeMount{{Name: "certs", MountPath: "/etc/certs"}, {Name: "data", MountPath: "/data"}}
+	givenTd.Spec.Template.Spec.Volumes = []v12.Volume{
+		{Name: "certs", VolumeSource: v12.VolumeSource{Secret: &v12.SecretVolumeSource{SecretName: "test-certs"}}},
+		{Name: "data", VolumeSource: v12.VolumeSource{EmptyDir: &v12.EmptyDirVolumeSource{}}},
+	}
+
+	mockStatusProvider := &automock.StatusProvider{}
+	defer mockStatusProvider.AssertExpectations(t)
+	mockStatusProvider.On("GetExecutionsInProgress", &suite).Return(nil).Once()
+	mockStatusProvider.On("MarkAsScheduled", suite.Status, "test-name", "test-namespace", "postgres", "oct-tp-test-all-test-name-postgres-0").Return(suite.Status, nil)
+
+	fakeCli, sch, err := getFakeClient(&givenTd)
+	require.NoError(t, err)
+
+	mockRecorder := &automock.EventRecorder{}
+	mockRecorder.On("SuiteEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	sut := scheduler.NewService(mockStatusProvider, fakeCli, fakeCli, sch, mockRecorder, rlog.Log)
+	// WHEN
+	pod, _, err := sut.TrySchedule(&suite)
+	// THEN
+	require.NoError(t, err)
+	require.NotNil(t, pod)
+	assert.Equal(t, []v12.EnvVar{
+		{Name: "CLUSTER_URL", Value: "https://cluster.local"},
+		{Name: "DEBUG", Value: "true"},
+		{Name: "DB_HOST", Value: "postgres"},
+	}, pod.Spec.Containers[0].Env)
+	assert.Equal(t, []v12.EnvFromSource{
+		{ConfigMapRef: &v12.ConfigMapEnvSource{LocalObjectReference: v12.LocalObjectReference{Name: "test-config"}}},
+		{SecretRef: &v12.SecretEnvSource{LocalObjectReference: v12.LocalObjectReference{Name: "suite-credentials"}}},
+	}, pod.Spec.Containers[0].EnvFrom)
+	assert.Equal(t, []v12.VolumeMount{{Name: "certs", MountPath: "/etc/certs"}, {Name: "data", MountPath: "/data"}}, pod.Spec.Containers[0].VolumeMounts)
+	assert.Equal(t, []v12.Volume{
+		{Name: "certs", VolumeSource: v12.VolumeSource{Secret: &v12.SecretVolumeSource{SecretName: "suite-certs"}}},
+		{Name: "data", VolumeSource: v12.VolumeSource{EmptyDir: &v12.EmptyDirVolumeSource{}}},
+	}, pod.Spec.Volumes)
+	assert.Equal(t, []v12.EnvVar{
+		{Name: "CLUSTER_URL", Value: "https://cluster.local"},
+		{Name: "DB_HOST", Value: "postgres"},
+	}, pod.Spec.InitContainers[0].Env)
+	assert.Equal(t, []v12.VolumeMount{{Name: "certs", MountPath: "/etc/certs"}}, pod.Spec.InitContainers[0].VolumeMounts)
+}
+
 // fake clients which supports Occtopus CRDs
 func getFakeClient(initObjects ...runtime.Object) (client.Client, *runtime.Scheme, error) {
 	sch := scheme.Scheme
@@ -437,3 +871,13 @@ func givenUninitializedSuite(givenTr v1alpha1.TestResult) v1alpha1.ClusterTestSu
 	}
 	return uninitializedSuite
 }
+
+func givenTestDefinitionWithRequests(name, cpu, memory string) *v1alpha1.TestDefinition {
+	td := givenTestDefinition()
+	td.Name = name
+	td.Spec.Template.Spec.Containers[0].Resources.Requests = v12.ResourceList{
+		v12.ResourceCPU:    resource.MustParse(cpu),
+		v12.ResourceMemory: resource.MustParse(memory),
+	}
+	return &td
+}
@@ -0,0 +1,41 @@
+package status
+
+import (
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const abortedMessage = "Suite aborted on user request"
+
+// AbortSuite finishes the suite on user request. Executions of tests and the setup in progress are interrupted,
+// so that their testing pods can be deleted, and tests that were not executed are skipped. Results collected
+// so far are kept. The teardown is not aborted, so it still cleans up after the suite. If all tests are already
+// finished, the status is not changed.
+func (s *Service) AbortSuite(suite v1alpha1.GenericTestSuite) *v1alpha1.TestSuiteStatus {
+	out := suite.GetStatus().DeepCopy()
+	if s.isTestingFinished(*out) {
+		return out
+	}
+	now := s.nowProvider()
+	for idx := range out.Results {
+		s.interruptExecutions(&out.Results[idx], now, v1alpha1.ExecutionReasonSuiteAborted, abortedMessage)
+		wasSkipped := out.Results[idx].Status == v1alpha1.TestSkipped
+		out.Results[idx].Status = s.finalTestStatus(out.Results[idx], *suite.GetSpec())
+		if out.Results[idx].Status == v1alpha1.TestSkipped && !wasSkipped {
+			out.Results[idx].Reason = v1alpha1.TestReasonSuiteAborted
+			out.Results[idx].Message = abortedMessage
+		}
+	}
+	if out.Setup != nil {
+		s.interruptExecutions(out.Setup, now, v1alpha1.ExecutionReasonSuiteAborted, abortedMessage)
+		out.Setup.Status = s.calculateHookStatus(*out.Setup)
+		if out.Setup.Status == v1alpha1.TestNotYetScheduled {
+			out.Setup.Status = v1alpha1.TestSkipped
+			out.Setup.Reason = v1alpha1.TestReasonSuiteAborted
+			out.Setup.Message = abortedMessage
+		}
+	}
+	s.SetSuiteCondition(out, v1alpha1.SuiteAborted, "", abortedMessage)
+	out.CompletionTime = &metav1.Time{Time: now}
+	return out
+}
@@ -0,0 +1,52 @@
+package status
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"k8s.io/api/core/v1"
+)
+
+// getContainerResults returns how init containers and containers of the testing pod terminated
+func (s *Service) getContainerResults(pod v1.Pod) []v1alpha1.ContainerResult {
+	var out []v1alpha1.ContainerResult
+	for _, cs := range pod.Status.InitContainerStatuses {
+		res := s.getContainerResult(cs)
+		res.InitContainer = true
+		out = append(out, res)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		out = append(out, s.getContainerResult(cs))
+	}
+	return out
+}
+
+func (s *Service) getContainerResult(cs v1.ContainerStatus) v1alpha1.ContainerResult {
+	out := v1alpha1.ContainerResult{
+		Name:         cs.Name,
+		RestartCount: cs.RestartCount,
+	}
+	if term := cs.State.Terminated; term != nil {
+		exitCode := term.ExitCode
+		out.ExitCode = &exitCode
+		out.Reason = term.Reason
+		out.Message = term.Message
+	}
+	return out
+}
+
+// getFailedContainerMessage describes the first container that terminated with a non-zero exit code,
+// or returns an empty string if there is no such container
+func (s *Service) getFailedContainerMessage(containers []v1alpha1.ContainerResult) string {
+	for _, c := range containers {
+		if c.ExitCode == nil || *c.ExitCode == 0 {
+			continue
+		}
+		msg := fmt.Sprintf("Container [%s] terminated with exit code [%d]", c.Name, *c.ExitCode)
+		if c.Reason != "" {
+			msg += fmt.Sprintf(", reason [%s]", c.Reason)
+		}
+		return msg
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package status
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/events"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// missingPodGracePeriod protects executions that were just scheduled, because their testing pods
+// may not be visible in the cache of the controller yet
+const missingPodGracePeriod = time.Minute
+
+// podReasonNodeLost is set by Kubernetes on pods in the Unknown phase when their node stopped responding
+const podReasonNodeLost = "NodeLost"
+
+// markLostExecutions marks executions as failed if their testing pods were deleted by someone else than Octopus,
+// e.g. by a user or because a node was drained, or if their node was lost. Such executions would never finish
+// otherwise and would block the suite. Failed executions are retried if the suite allows it.
+func (s *Service) markLostExecutions(suite v1alpha1.GenericTestSuite, stat *v1alpha1.TestSuiteStatus, pods []v1.Pod, rec EventRecorder) {
+	podsByName := make(map[string]v1.Pod, len(pods))
+	for _, pod := range pods {
+		podsByName[pod.Name] = pod
+	}
+	for _, tr := range stat.GetAllResults() {
+		for execID, exec := range tr.Executions {
+			if s.isExecutionFinished(exec) {
+				continue
+			}
+			reason, msg, lost := s.getLostReason(exec, podsByName)
+			if !lost {
+				continue
+			}
+			exec.PodPhase = v1.PodFailed
+			exec.CompletionTime = &metav1.Time{Time: s.nowProvider()}
+			exec.Reason = reason
+			exec.Message = msg
+			tr.Executions[execID] = exec
+			rec.TestFailureEvent(suite, *tr, events.ReasonExecutionLost, "Execution [%s] of test [name: %s, namespace: %s] failed: %s", exec.ID, tr.Name, tr.Namespace, msg)
+		}
+	}
+}
+
+// getLostReason returns the reason and the message of an unfinished execution that will never finish,
+// because its testing pod is deleted or its node is lost
+func (s *Service) getLostReason(exec v1alpha1.TestExecution, podsByName map[string]v1.Pod) (string, string, bool) {
+	pod, found := podsByName[exec.ID]
+	switch {
+	case !found:
+		// pod that was never observed may be missing only in the cache
+		if exec.PodPhase == "" && (exec.StartTime == nil || s.nowProvider().Sub(exec.StartTime.Time) <= missingPodGracePeriod) {
+			return "", "", false
+		}
+		return v1alpha1.ExecutionReasonPodDeleted, "Testing pod was deleted before the execution finished", true
+	case pod.DeletionTimestamp != nil:
+		return v1alpha1.ExecutionReasonPodDeleted, "Testing pod is being deleted before the execution finished", true
+	case pod.Status.Phase == v1.PodUnknown && pod.Status.Reason == podReasonNodeLost:
+		return v1alpha1.ExecutionReasonNodeLost, fmt.Sprintf("Node [%s] of the testing pod was lost", pod.Spec.NodeName), true
+	}
+	return "", "", false
+}
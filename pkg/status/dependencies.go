@@ -0,0 +1,134 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/humanerr"
+	"github.com/pkg/errors"
+)
+
+type defKey struct {
+	name      string
+	namespace string
+}
+
+func (k defKey) String() string {
+	return fmt.Sprintf("%s/%s", k.namespace, k.name)
+}
+
+// validateDependencies builds a graph of dependencies between definitions and checks that all dependencies
+// are selected by the suite and that there are no cycles
+func validateDependencies(defs []v1alpha1.TestDefinition) error {
+	graph := make(map[defKey][]defKey, len(defs))
+	var keys []defKey
+	for _, def := range defs {
+		key := defKey{name: def.Name, namespace: def.Namespace}
+		keys = append(keys, key)
+		graph[key] = nil
+	}
+	for _, def := range defs {
+		key := defKey{name: def.Name, namespace: def.Namespace}
+		for _, dep := range def.Spec.DependsOn {
+			depKey := defKey{name: dep.Name, namespace: dep.Namespace}
+			if _, found := graph[depKey]; !found {
+				msg := fmt.Sprintf("Test Definition [name: %s, namespace: %s] depends on Test Definition [name: %s, namespace: %s] which is not selected by the suite", def.Name, def.Namespace, dep.Name, dep.Namespace)
+				return humanerr.NewError(errors.New(msg), msg)
+			}
+			graph[key] = append(graph[key], depKey)
+		}
+	}
+
+	if cycle := findCycle(keys, graph); cycle != nil {
+		var names []string
+		for _, k := range cycle {
+			names = append(names, k.String())
+		}
+		msg := fmt.Sprintf("Dependencies between Test Definitions form a cycle: %s", strings.Join(names, " -> "))
+		return humanerr.NewError(errors.New(msg), msg)
+	}
+	return nil
+}
+
+// findCycle returns a path that starts and ends with the same node, or nil if the graph is acyclic
+func findCycle(keys []defKey, graph map[defKey][]defKey) []defKey {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[defKey]int, len(keys))
+	var path []defKey
+
+	var visit func(k defKey) []defKey
+	visit = func(k defKey) []defKey {
+		state[k] = inProgress
+		path = append(path, k)
+		for _, next := range graph[k] {
+			switch state[next] {
+			case inProgress:
+				for idx, p := range path {
+					if p == next {
+						return append(append([]defKey{}, path[idx:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[k] = done
+		return nil
+	}
+
+	for _, k := range keys {
+		if state[k] != unvisited {
+			continue
+		}
+		if cycle := visit(k); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// skipTestsWithUnsuccessfulDependencies marks tests as skipped if any of their dependencies failed or was skipped.
+// Skipping is propagated to tests that depend on skipped tests.
+func (s *Service) skipTestsWithUnsuccessfulDependencies(stat *v1alpha1.TestSuiteStatus, spec v1alpha1.TestSuiteSpec) {
+	for changed := true; changed; {
+		changed = false
+		for idx, tr := range stat.Results {
+			if tr.Status != v1alpha1.TestNotYetScheduled {
+				continue
+			}
+			for _, dep := range tr.DependsOn {
+				depStatus, found := s.getUnsuccessfulStatus(*stat, dep, spec)
+				if !found {
+					continue
+				}
+				stat.Results[idx].Status = v1alpha1.TestSkipped
+				stat.Results[idx].Reason = v1alpha1.TestReasonDependencyNotSucceeded
+				stat.Results[idx].Message = fmt.Sprintf("Test [name: %s, namespace: %s] that this test depends on has status [%s]", dep.Name, dep.Namespace, depStatus)
+				changed = true
+				break
+			}
+		}
+	}
+}
+
+// getUnsuccessfulStatus returns the status of the test if it failed or was skipped. A test with variants
+// is unsuccessful if any of its variants is.
+func (s *Service) getUnsuccessfulStatus(stat v1alpha1.TestSuiteStatus, ref v1alpha1.TestDefReference, spec v1alpha1.TestSuiteSpec) (v1alpha1.TestStatus, bool) {
+	for _, tr := range stat.Results {
+		if tr.Name != ref.Name || tr.Namespace != ref.Namespace {
+			continue
+		}
+		if spec.IsTestFailed(tr.Status) || tr.Status == v1alpha1.TestSkipped || tr.Status == v1alpha1.TestUnknown {
+			return tr.Status, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,34 @@
+package status
+
+import "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+
+// PendingEvents collects Events about changes in the suite status. A status that fails to be persisted is
+// computed again on the next reconcile, so Events are recorded only once the status is persisted.
+type PendingEvents struct {
+	recorder EventRecorder
+	events   []func(rec EventRecorder)
+}
+
+func newPendingEvents(recorder EventRecorder) *PendingEvents {
+	return &PendingEvents{recorder: recorder}
+}
+
+func (p *PendingEvents) SuiteEvent(suite v1alpha1.GenericTestSuite, eventType, reason, messageFmt string, args ...interface{}) {
+	p.events = append(p.events, func(rec EventRecorder) {
+		rec.SuiteEvent(suite, eventType, reason, messageFmt, args...)
+	})
+}
+
+func (p *PendingEvents) TestFailureEvent(suite v1alpha1.GenericTestSuite, res v1alpha1.TestResult, reason, messageFmt string, args ...interface{}) {
+	p.events = append(p.events, func(rec EventRecorder) {
+		rec.TestFailureEvent(suite, res, reason, messageFmt, args...)
+	})
+}
+
+// Record records collected Events. It has to be called once the status is persisted.
+func (p *PendingEvents) Record() {
+	for _, fn := range p.events {
+		fn(p.recorder)
+	}
+	p.events = nil
+}
@@ -0,0 +1,100 @@
+package status
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/events"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// stopIfFailureThresholdReached stops the suite if the number of failed tests reached FailFast or MaxFailures.
+// Tests that were not executed yet are skipped. Tests in progress are interrupted if the suite requests it,
+// otherwise they are finished once their current executions finish. Returns true if the suite is stopped.
+func (s *Service) stopIfFailureThresholdReached(stat *v1alpha1.TestSuiteStatus, spec v1alpha1.TestSuiteSpec) bool {
+	threshold := spec.GetFailureThreshold()
+	if threshold == 0 || s.countFailedTests(*stat, spec) < threshold {
+		return false
+	}
+
+	msg := s.stoppedMessage(threshold)
+	for idx, tr := range stat.Results {
+		if tr.Status != v1alpha1.TestNotYetScheduled && tr.Status != v1alpha1.TestScheduled && tr.Status != v1alpha1.TestRunning {
+			continue
+		}
+		inProgress := false
+		for execID, exec := range tr.Executions {
+			if s.isExecutionFinished(exec) {
+				continue
+			}
+			if !spec.AbortRunningOnFailure {
+				inProgress = true
+				continue
+			}
+			exec.PodPhase = v1.PodFailed
+			exec.CompletionTime = &metav1.Time{Time: s.nowProvider()}
+			exec.Reason = v1alpha1.ExecutionReasonAborted
+			exec.Message = msg
+			stat.Results[idx].Executions[execID] = exec
+		}
+		if inProgress {
+			continue
+		}
+		stat.Results[idx].Status = s.finalTestStatus(stat.Results[idx], spec)
+		if stat.Results[idx].Status == v1alpha1.TestSkipped {
+			stat.Results[idx].Reason = v1alpha1.TestReasonSuiteStopped
+			stat.Results[idx].Message = msg
+		}
+	}
+	return true
+}
+
+// countFailedTests counts tests that failed, including tests that are still running but cannot succeed anymore,
+// because one of their executions failed and there are no retries
+func (s *Service) countFailedTests(stat v1alpha1.TestSuiteStatus, spec v1alpha1.TestSuiteSpec) int64 {
+	var out int64
+	for _, tr := range stat.Results {
+		if spec.IsTestFailed(tr.Status) {
+			out++
+			continue
+		}
+		if spec.MaxRetries > 0 || tr.Status == v1alpha1.TestSkipped {
+			continue
+		}
+		for _, exec := range tr.Executions {
+			if exec.PodPhase == v1.PodFailed {
+				out++
+				break
+			}
+		}
+	}
+	return out
+}
+
+// markAsStopped sets the reason of the suite condition, so that the scheduler does not create new testing pods
+// and users know why the suite ended earlier
+func (s *Service) markAsStopped(suite v1alpha1.GenericTestSuite, stat *v1alpha1.TestSuiteStatus, rec EventRecorder) {
+	cond := s.getSuiteCondition(*stat)
+	if cond != v1alpha1.SuiteRunning && cond != v1alpha1.SuiteFailed {
+		return
+	}
+	threshold := suite.GetSpec().GetFailureThreshold()
+	if !s.isStopped(*suite.GetStatus()) {
+		rec.SuiteEvent(suite, v1.EventTypeWarning, events.ReasonFailureThresholdReached, "Suite stopped because [%d] test(s) failed, no more testing pods will be created", threshold)
+	}
+	s.SetSuiteCondition(stat, cond, v1alpha1.ReasonFailureThresholdReached, s.stoppedMessage(threshold))
+}
+
+func (s *Service) isStopped(stat v1alpha1.TestSuiteStatus) bool {
+	for _, cond := range stat.Conditions {
+		if cond.Status == v1alpha1.StatusTrue && cond.Reason == v1alpha1.ReasonFailureThresholdReached {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) stoppedMessage(threshold int64) string {
+	return fmt.Sprintf("Suite stopped because [%d] test(s) failed", threshold)
+}
@@ -0,0 +1,140 @@
+package status
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"k8s.io/api/core/v1"
+)
+
+// InitializeHooks adds results of the setup and teardown to the status of an initialized suite.
+// Hooks are not executed if the suite is already finished, because there are no tests to execute.
+func (s *Service) InitializeHooks(stat *v1alpha1.TestSuiteStatus, setup, teardown *v1alpha1.TestDefinition) {
+	if s.isTestingFinished(*stat) {
+		return
+	}
+	if setup != nil {
+		stat.Setup = s.newHookResult(*setup)
+	}
+	if teardown != nil {
+		stat.Teardown = s.newHookResult(*teardown)
+	}
+}
+
+func (s *Service) newHookResult(def v1alpha1.TestDefinition) *v1alpha1.TestResult {
+	return &v1alpha1.TestResult{
+		Name:       def.Name,
+		Namespace:  def.Namespace,
+		Status:     v1alpha1.TestNotYetScheduled,
+		Executions: make([]v1alpha1.TestExecution, 0),
+		Timeout:    def.Spec.Timeout,
+	}
+}
+
+func (s *Service) updateHookStatuses(stat *v1alpha1.TestSuiteStatus) {
+	for _, hook := range []*v1alpha1.TestResult{stat.Setup, stat.Teardown} {
+		if hook != nil {
+			hook.Status = s.calculateHookStatus(*hook)
+		}
+	}
+}
+
+// calculateHookStatus returns the status of the setup or teardown, which are executed only once
+func (s *Service) calculateHookStatus(hook v1alpha1.TestResult) v1alpha1.TestStatus {
+	if hook.Status == v1alpha1.TestSkipped {
+		return v1alpha1.TestSkipped
+	}
+	if len(hook.Executions) == 0 {
+		return v1alpha1.TestNotYetScheduled
+	}
+	switch hook.Executions[len(hook.Executions)-1].PodPhase {
+	case "":
+		return v1alpha1.TestScheduled
+	case v1.PodSucceeded:
+		return v1alpha1.TestSucceeded
+	case v1.PodFailed:
+		return v1alpha1.TestFailed
+	default:
+		return v1alpha1.TestRunning
+	}
+}
+
+// skipHooksIfNothingExecuted skips hooks that will never be executed once the testing is finished.
+// The setup cannot be executed after tests, and the teardown has nothing to clean up if neither the setup
+// nor any test was executed, e.g. because all tests were skipped.
+func (s *Service) skipHooksIfNothingExecuted(stat *v1alpha1.TestSuiteStatus) {
+	msg := "Suite finished without executing any test"
+	if stat.Setup != nil && stat.Setup.Status == v1alpha1.TestNotYetScheduled {
+		stat.Setup.Status = v1alpha1.TestSkipped
+		stat.Setup.Reason = v1alpha1.TestReasonNoTestsExecuted
+		stat.Setup.Message = msg
+	}
+	if stat.Teardown == nil || stat.Teardown.Status != v1alpha1.TestNotYetScheduled {
+		return
+	}
+	if stat.Setup != nil && len(stat.Setup.Executions) > 0 {
+		return
+	}
+	for _, res := range stat.Results {
+		if len(res.Executions) > 0 {
+			return
+		}
+	}
+	stat.Teardown.Status = v1alpha1.TestSkipped
+	stat.Teardown.Reason = v1alpha1.TestReasonNoTestsExecuted
+	stat.Teardown.Message = msg
+}
+
+func (s *Service) isHookFinished(hook v1alpha1.TestResult) bool {
+	return hook.Status == v1alpha1.TestSucceeded || hook.Status == v1alpha1.TestFailed || hook.Status == v1alpha1.TestSkipped
+}
+
+func (s *Service) isSetupFailed(stat v1alpha1.TestSuiteStatus) bool {
+	return stat.Setup != nil && stat.Setup.Status == v1alpha1.TestFailed
+}
+
+// skipTestsIfSetupFailed skips all tests that were not executed, because they cannot be executed without the setup
+func (s *Service) skipTestsIfSetupFailed(stat *v1alpha1.TestSuiteStatus) {
+	if !s.isSetupFailed(*stat) {
+		return
+	}
+	msg := s.setupFailedMessage(*stat.Setup)
+	for idx, tr := range stat.Results {
+		if tr.Status != v1alpha1.TestNotYetScheduled {
+			continue
+		}
+		stat.Results[idx].Status = v1alpha1.TestSkipped
+		stat.Results[idx].Reason = v1alpha1.TestReasonSetupFailed
+		stat.Results[idx].Message = msg
+	}
+}
+
+func (s *Service) setupFailedMessage(setup v1alpha1.TestResult) string {
+	return fmt.Sprintf("Setup [name: %s, namespace: %s] failed", setup.Name, setup.Namespace)
+}
+
+// resetHooks prepares the setup and teardown to be executed again. Their executions are moved to the history
+// of previous executions.
+func (s *Service) resetHooks(stat *v1alpha1.TestSuiteStatus) {
+	for _, hook := range []*v1alpha1.TestResult{stat.Setup, stat.Teardown} {
+		if hook == nil {
+			continue
+		}
+		hook.PreviousExecutions = append(hook.PreviousExecutions, hook.Executions...)
+		hook.Executions = make([]v1alpha1.TestExecution, 0)
+		hook.Status = v1alpha1.TestNotYetScheduled
+		hook.Reason = ""
+		hook.Message = ""
+	}
+}
+
+// withoutHooks removes the setup and teardown from TestDefinitions matching the suite, so they are not executed as tests
+func (s *Service) withoutHooks(defs []v1alpha1.TestDefinition, spec v1alpha1.TestSuiteSpec) []v1alpha1.TestDefinition {
+	out := make([]v1alpha1.TestDefinition, 0, len(defs))
+	for _, def := range defs {
+		if !spec.IsHook(def) {
+			out = append(out, def)
+		}
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package status
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/events"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// updatePause records when the suite was paused and for how long it was paused in total,
+// so that the time when the suite was paused can be excluded from SuiteTimeout
+func (s *Service) updatePause(suite v1alpha1.GenericTestSuite, stat *v1alpha1.TestSuiteStatus, rec EventRecorder) {
+	paused := suite.GetSpec().Paused
+	switch {
+	case paused && stat.PausedAt == nil:
+		stat.PausedAt = &metav1.Time{Time: s.nowProvider()}
+		rec.SuiteEvent(suite, v1.EventTypeNormal, events.ReasonPaused, "Suite paused, no more testing pods will be created until it is resumed")
+	case !paused && stat.PausedAt != nil:
+		var total time.Duration
+		if stat.PausedDuration != nil {
+			total = stat.PausedDuration.Duration
+		}
+		pause := s.nowProvider().Sub(stat.PausedAt.Time)
+		stat.PausedDuration = &metav1.Duration{Duration: total + pause}
+		stat.PausedAt = nil
+		rec.SuiteEvent(suite, v1.EventTypeNormal, events.ReasonResumed, "Suite resumed after [%s]", pause.Round(time.Second))
+	}
+}
+
+// getSuiteRunningTime returns the time elapsed since the suite started. If the suite requests it,
+// the time when the suite was paused is not counted.
+func (s *Service) getSuiteRunningTime(stat v1alpha1.TestSuiteStatus, spec v1alpha1.TestSuiteSpec, now time.Time) time.Duration {
+	out := now.Sub(stat.StartTime.Time)
+	if !spec.PauseSuiteTimeout {
+		return out
+	}
+	if stat.PausedDuration != nil {
+		out -= stat.PausedDuration.Duration
+	}
+	if stat.PausedAt != nil {
+		out -= now.Sub(stat.PausedAt.Time)
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/events"
+	"github.com/kyma-incubator/octopus/pkg/humanerr"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// waiting reasons of containers that will not start without changing the TestDefinition or the cluster
+var stuckWaitingReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+}
+
+// markStuckExecutions marks executions with testing pods stuck in the Pending phase for longer than PendingTimeout
+// as failed. Testing pods of such executions have to be deleted.
+func (s *Service) markStuckExecutions(suite v1alpha1.GenericTestSuite, stat *v1alpha1.TestSuiteStatus, pods []v1.Pod, rec EventRecorder) {
+	timeout := s.getPendingTimeout(*suite.GetSpec())
+	for _, pod := range pods {
+		if pod.Status.Phase != v1.PodPending {
+			continue
+		}
+		now := s.nowProvider()
+		if now.Sub(pod.CreationTimestamp.Time) <= timeout {
+			continue
+		}
+		err := s.checkPendingPod(pod)
+		if err == nil {
+			continue
+		}
+		msg := err.Error()
+		if hErr, ok := humanerr.GetHumanReadableError(err); ok {
+			msg = hErr.Message
+		}
+		for _, tr := range stat.GetAllResults() {
+			if tr.Name != pod.Labels[v1alpha1.LabelKeyTestDefName] || tr.Namespace != pod.Namespace {
+				continue
+			}
+			for execID, exec := range tr.Executions {
+				if exec.ID != pod.Name || s.isExecutionFinished(exec) {
+					continue
+				}
+				exec.PodPhase = v1.PodFailed
+				exec.CompletionTime = &metav1.Time{Time: now}
+				exec.Reason = v1alpha1.ExecutionReasonStuckPending
+				exec.Message = msg
+				tr.Executions[execID] = exec
+				rec.TestFailureEvent(suite, *tr, events.ReasonExecutionStuckPending, "Execution [%s] of test [name: %s, namespace: %s] was stuck in Pending for longer than [%s]: %s", exec.ID, tr.Name, tr.Namespace, timeout, msg)
+			}
+		}
+	}
+}
+
+// checkPendingPod returns an error with a human-readable message if the pending testing pod will not start
+// without user action, e.g. because its image does not exist
+func (s *Service) checkPendingPod(pod v1.Pod) error {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodScheduled && cond.Status == v1.ConditionFalse && cond.Reason == v1.PodReasonUnschedulable {
+			return humanerr.NewError(errors.Errorf("pod is unschedulable: %s", cond.Message), fmt.Sprintf("Testing pod cannot be scheduled: %s", cond.Message))
+		}
+	}
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		waiting := cs.State.Waiting
+		if waiting == nil || !stuckWaitingReasons[waiting.Reason] {
+			continue
+		}
+		cause := errors.Errorf("container [%s] is waiting with reason [%s]: %s", cs.Name, waiting.Reason, waiting.Message)
+		if waiting.Reason == "CreateContainerConfigError" {
+			return humanerr.NewError(cause, fmt.Sprintf("Invalid configuration of container [%s]: %s", cs.Name, waiting.Message))
+		}
+		return humanerr.NewError(cause, fmt.Sprintf("Cannot pull image [%s] of container [%s]", cs.Image, cs.Name))
+	}
+	return nil
+}
+
+func (s *Service) getPendingTimeout(spec v1alpha1.TestSuiteSpec) time.Duration {
+	if spec.PendingTimeout == nil {
+		return v1alpha1.DefaultPendingTimeout
+	}
+	return spec.PendingTimeout.Duration
+}
+
+// getStuckFailedTests returns failed tests with executions that were stuck in the Pending phase.
+// Such tests failed because of configuration problems, not because of the tested code.
+func (s *Service) getStuckFailedTests(stat v1alpha1.TestSuiteStatus, spec v1alpha1.TestSuiteSpec) []string {
+	var out []string
+	for _, tr := range stat.Results {
+		if !spec.IsTestFailed(tr.Status) {
+			continue
+		}
+		for _, exec := range tr.Executions {
+			if exec.Reason == v1alpha1.ExecutionReasonStuckPending {
+				out = append(out, fmt.Sprintf("%s/%s", tr.Namespace, tr.Name))
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (s *Service) stuckPendingMessage(tests []string) string {
+	return fmt.Sprintf("Testing pods of tests [%s] were stuck in Pending", strings.Join(tests, ", "))
+}
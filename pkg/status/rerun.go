@@ -0,0 +1,53 @@
+package status
+
+import (
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RerunFailedTests reopens a finished suite. Failed tests and tests that were skipped because of other failed tests,
+// or because the suite was aborted or timed out, are scheduled again, and their executions are moved to the history of previous executions.
+// Returns the new status and the number of tests to rerun. The status is not changed if there is nothing to rerun.
+func (s *Service) RerunFailedTests(suite v1alpha1.GenericTestSuite) (*v1alpha1.TestSuiteStatus, int) {
+	out := suite.GetStatus().DeepCopy()
+	rerun := 0
+	for idx, tr := range out.Results {
+		if !s.shouldRerun(tr, *suite.GetSpec()) {
+			continue
+		}
+		out.Results[idx].PreviousExecutions = append(tr.PreviousExecutions, tr.Executions...)
+		out.Results[idx].Executions = make([]v1alpha1.TestExecution, 0)
+		out.Results[idx].Status = v1alpha1.TestNotYetScheduled
+		out.Results[idx].Reason = ""
+		out.Results[idx].Message = ""
+		rerun++
+	}
+	if rerun == 0 {
+		return out, 0
+	}
+
+	// the setup may be needed by rerun tests and the teardown cleans up after them
+	s.resetHooks(out)
+	// suite timeout applies to every run separately
+	out.StartTime = &metav1.Time{Time: s.nowProvider()}
+	out.CompletionTime = nil
+	out.PausedAt = nil
+	out.PausedDuration = nil
+	s.SetSuiteCondition(out, v1alpha1.SuiteRunning, "", "")
+	// dependencies that are not rerun may still make the test impossible to execute
+	s.skipTestsWithUnsuccessfulDependencies(out, *suite.GetSpec())
+	return out, rerun
+}
+
+func (s *Service) shouldRerun(tr v1alpha1.TestResult, spec v1alpha1.TestSuiteSpec) bool {
+	if spec.IsTestFailed(tr.Status) {
+		return true
+	}
+	switch tr.Status {
+	case v1alpha1.TestSkipped:
+		return tr.Reason == v1alpha1.TestReasonDependencyNotSucceeded || tr.Reason == v1alpha1.TestReasonSuiteStopped ||
+			tr.Reason == v1alpha1.TestReasonSetupFailed || tr.Reason == v1alpha1.TestReasonSuiteAborted ||
+			tr.Reason == v1alpha1.TestReasonSuiteTimedOut
+	}
+	return false
+}
@@ -2,34 +2,46 @@ package status
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/events"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type NowProvider func() time.Time
 
+type EventRecorder interface {
+	SuiteEvent(suite v1alpha1.GenericTestSuite, eventType, reason, messageFmt string, args ...interface{})
+	TestFailureEvent(suite v1alpha1.GenericTestSuite, res v1alpha1.TestResult, reason, messageFmt string, args ...interface{})
+}
+
 type Service struct {
 	nowProvider NowProvider
+	recorder    EventRecorder
 }
 
-func NewService(nowProvider NowProvider) *Service {
-	return &Service{nowProvider: nowProvider}
+func NewService(nowProvider NowProvider, recorder EventRecorder) *Service {
+	return &Service{nowProvider: nowProvider, recorder: recorder}
 }
 
-func (s *Service) EnsureStatusIsUpToDate(suite v1alpha1.ClusterTestSuite, pods []v1.Pod) (*v1alpha1.TestSuiteStatus, error) {
-	out := suite.Status.DeepCopy()
+// EnsureStatusIsUpToDate returns the status updated with phases of testing pods and Events about the changes.
+// Events have to be recorded only once the returned status is persisted.
+func (s *Service) EnsureStatusIsUpToDate(suite v1alpha1.GenericTestSuite, pods []v1.Pod) (*v1alpha1.TestSuiteStatus, *PendingEvents, error) {
+	out := suite.GetStatus().DeepCopy()
+	pending := newPendingEvents(s.recorder)
 	for _, pod := range pods {
-		for idx, tr := range out.Results {
+		for _, tr := range out.GetAllResults() {
 			if tr.Name == pod.Labels[v1alpha1.LabelKeyTestDefName] && tr.Namespace == pod.Namespace {
 				// find execution
 				for execID, exec := range tr.Executions {
 					if exec.ID == pod.Name {
 						prev := exec.PodPhase
-						if pod.Status.Phase != prev {
-							out.Results[idx].Executions[execID] = s.adjustTestExec(exec, pod)
+						if pod.Status.Phase != prev && !s.isExecutionFinished(exec) {
+							tr.Executions[execID] = s.adjustTestExec(exec, pod)
+							s.recordExecutionFinished(suite, *tr, tr.Executions[execID], pending)
 						}
 					}
 				}
@@ -37,30 +49,165 @@ func (s *Service) EnsureStatusIsUpToDate(suite v1alpha1.ClusterTestSuite, pods [
 		}
 	}
 
+	s.markTimedOutExecutions(suite, out, pending)
+	s.markStuckExecutions(suite, out, pods, pending)
+	s.markLostExecutions(suite, out, pods, pending)
+	s.updateHookStatuses(out)
+	if s.isTestingFinished(*out) {
+		// only the teardown can be in progress once the suite has its final condition
+		s.skipHooksIfNothingExecuted(out)
+		return out, pending, nil
+	}
+
 	for idx, res := range out.Results {
-		newState := s.calculateTestStatus(res, suite.Spec.MaxRetries, suite.Spec.Count)
+		newState := s.calculateTestStatus(res, suite.GetSpec().MaxRetries, suite.GetSpec().Count)
 		if res.Status != newState {
 			out.Results[idx].Status = newState
 		}
 	}
-	adjusted := s.adjustSuiteCondition(*out)
+	s.skipTestsWithUnsuccessfulDependencies(out, *suite.GetSpec())
+	s.skipTestsIfSetupFailed(out)
+	s.updatePause(suite, out, pending)
+
+	if !s.IsFinished(suite) && out.StartTime != nil {
+		now := s.nowProvider()
+		if timeout := s.getSuiteTimeout(*suite.GetSpec()); s.getSuiteRunningTime(*out, *suite.GetSpec(), now) > timeout {
+			s.interruptSuite(out, *suite.GetSpec(), now, v1alpha1.ExecutionReasonSuiteTimedOut, v1alpha1.TestReasonSuiteTimedOut, fmt.Sprintf("Suite exceeded timeout [%s]", timeout))
+			pending.SuiteEvent(suite, v1.EventTypeWarning, events.ReasonSuiteTimedOut, "Suite exceeded timeout [%s], running tests were interrupted", timeout)
+			s.SetSuiteCondition(out, v1alpha1.SuiteError, v1alpha1.ReasonSuiteTimeout, fmt.Sprintf("Suite exceeded timeout [%s], running tests were interrupted", timeout))
+			out.CompletionTime = &metav1.Time{Time: now}
+			s.skipHooksIfNothingExecuted(out)
+			return out, pending, nil
+		}
+	}
+	stopped := s.stopIfFailureThresholdReached(out, *suite.GetSpec())
+	adjusted := s.adjustSuiteCondition(*out, *suite.GetSpec())
 	out = &adjusted
-	return out, nil
+	if stopped {
+		s.markAsStopped(suite, out, pending)
+	}
+	if s.isTestingFinished(*out) {
+		s.skipHooksIfNothingExecuted(out)
+	}
+	return out, pending, nil
 }
 
 func (s *Service) adjustTestExec(exec v1alpha1.TestExecution, pod v1.Pod) v1alpha1.TestExecution {
 	exec.PodPhase = pod.Status.Phase
 	if exec.PodPhase == v1.PodSucceeded {
 		exec.CompletionTime = &metav1.Time{Time: s.nowProvider()}
+		exec.Containers = s.getContainerResults(pod)
 	} else if exec.PodPhase == v1.PodFailed {
 		exec.CompletionTime = &metav1.Time{Time: s.nowProvider()}
 		exec.Reason = pod.Status.Reason
 		exec.Message = pod.Status.Message
+		exec.Containers = s.getContainerResults(pod)
+		if exec.Message == "" {
+			// the pod status is usually empty for a failed test, so the failed container explains the failure
+			exec.Message = s.getFailedContainerMessage(exec.Containers)
+		}
 	}
 	return exec
 }
 
+func (s *Service) recordExecutionFinished(suite v1alpha1.GenericTestSuite, tr v1alpha1.TestResult, exec v1alpha1.TestExecution, rec EventRecorder) {
+	switch exec.PodPhase {
+	case v1.PodSucceeded:
+		rec.SuiteEvent(suite, v1.EventTypeNormal, events.ReasonExecutionSucceeded, "Execution [%s] of test [name: %s, namespace: %s] succeeded", exec.ID, tr.Name, tr.Namespace)
+	case v1.PodFailed:
+		msg := fmt.Sprintf("Execution [%s] of test [name: %s, namespace: %s] failed", exec.ID, tr.Name, tr.Namespace)
+		if exec.Message != "" {
+			msg += ": " + exec.Message
+		}
+		rec.TestFailureEvent(suite, tr, events.ReasonExecutionFailed, "%s", msg)
+	}
+}
+
+// markTimedOutExecutions marks executions that take longer than the TestDefinition timeout as failed.
+// Testing pods of such executions are still running and have to be deleted.
+func (s *Service) markTimedOutExecutions(suite v1alpha1.GenericTestSuite, stat *v1alpha1.TestSuiteStatus, rec EventRecorder) {
+	for _, tr := range stat.GetAllResults() {
+		if tr.Timeout == nil {
+			continue
+		}
+		for execID, exec := range tr.Executions {
+			if exec.StartTime == nil || s.isExecutionFinished(exec) {
+				continue
+			}
+			now := s.nowProvider()
+			if now.Sub(exec.StartTime.Time) <= tr.Timeout.Duration {
+				continue
+			}
+			exec.PodPhase = v1.PodFailed
+			exec.CompletionTime = &metav1.Time{Time: now}
+			exec.Reason = v1alpha1.ExecutionReasonTimedOut
+			exec.Message = fmt.Sprintf("Test execution exceeded timeout [%s]", tr.Timeout.Duration)
+			tr.Executions[execID] = exec
+			rec.TestFailureEvent(suite, *tr, events.ReasonExecutionTimedOut, "Execution [%s] of test [name: %s, namespace: %s] exceeded timeout [%s]", exec.ID, tr.Name, tr.Namespace, tr.Timeout.Duration)
+		}
+	}
+}
+
+func (s *Service) getSuiteTimeout(spec v1alpha1.TestSuiteSpec) time.Duration {
+	if spec.SuiteTimeout == nil {
+		return v1alpha1.DefaultSuiteTimeout
+	}
+	return spec.SuiteTimeout.Duration
+}
+
+// interruptSuite marks all executions in progress, including the setup, as failed. Tests that cannot be finished anymore
+// are marked as failed if any of their executions failed, otherwise as skipped with the given reason.
+func (s *Service) interruptSuite(stat *v1alpha1.TestSuiteStatus, spec v1alpha1.TestSuiteSpec, now time.Time, execReason, testReason, msg string) {
+	for idx := range stat.Results {
+		s.interruptExecutions(&stat.Results[idx], now, execReason, msg)
+		wasSkipped := stat.Results[idx].Status == v1alpha1.TestSkipped
+		stat.Results[idx].Status = s.finalTestStatus(stat.Results[idx], spec)
+		if stat.Results[idx].Status == v1alpha1.TestSkipped && !wasSkipped {
+			stat.Results[idx].Reason = testReason
+			stat.Results[idx].Message = msg
+		}
+	}
+	if stat.Setup != nil {
+		s.interruptExecutions(stat.Setup, now, execReason, msg)
+		stat.Setup.Status = s.calculateHookStatus(*stat.Setup)
+	}
+}
+
+func (s *Service) interruptExecutions(tr *v1alpha1.TestResult, now time.Time, reason, msg string) {
+	for execID, exec := range tr.Executions {
+		if !s.isExecutionFinished(exec) {
+			exec.PodPhase = v1.PodFailed
+			exec.CompletionTime = &metav1.Time{Time: now}
+			exec.Reason = reason
+			exec.Message = msg
+			tr.Executions[execID] = exec
+		}
+	}
+}
+
+// finalTestStatus returns the status of a test that will not be executed anymore. Such a test is failed
+// if any of its executions failed, otherwise it is skipped.
+func (s *Service) finalTestStatus(tr v1alpha1.TestResult, spec v1alpha1.TestSuiteSpec) v1alpha1.TestStatus {
+	newState := s.calculateTestStatus(tr, spec.MaxRetries, spec.Count)
+	if newState == v1alpha1.TestSucceeded || newState == v1alpha1.TestFailed || newState == v1alpha1.TestFlaky {
+		return newState
+	}
+	for _, exec := range tr.Executions {
+		if exec.PodPhase == v1.PodFailed {
+			return v1alpha1.TestFailed
+		}
+	}
+	return v1alpha1.TestSkipped
+}
+
+func (s *Service) isExecutionFinished(exec v1alpha1.TestExecution) bool {
+	return exec.PodPhase == v1.PodSucceeded || exec.PodPhase == v1.PodFailed
+}
+
 func (s *Service) calculateTestStatus(tr v1alpha1.TestResult, maxRetries, count int64) v1alpha1.TestStatus {
+	if tr.Status == v1alpha1.TestSkipped {
+		return v1alpha1.TestSkipped
+	}
 	if len(tr.Executions) == 0 {
 		return v1alpha1.TestNotYetScheduled
 	}
@@ -68,7 +215,11 @@ func (s *Service) calculateTestStatus(tr v1alpha1.TestResult, maxRetries, count
 	if maxRetries > 0 {
 		var anySucceeded bool
 		var anyRunning bool
+		var anyFailed bool
 		for _, exec := range tr.Executions {
+			if exec.PodPhase == v1.PodFailed {
+				anyFailed = true
+			}
 			if exec.PodPhase == v1.PodSucceeded {
 				anySucceeded = true
 				break
@@ -78,6 +229,9 @@ func (s *Service) calculateTestStatus(tr v1alpha1.TestResult, maxRetries, count
 				break
 			}
 		}
+		if anySucceeded && anyFailed {
+			return v1alpha1.TestFlaky
+		}
 		if anySucceeded {
 			return v1alpha1.TestSucceeded
 		}
@@ -95,7 +249,7 @@ func (s *Service) calculateTestStatus(tr v1alpha1.TestResult, maxRetries, count
 		return v1alpha1.TestRunning
 	}
 
-	var anyFailed bool
+	var anyFailed, anySucceeded bool
 	for _, exec := range tr.Executions {
 		switch exec.PodPhase {
 		case v1.PodPending:
@@ -104,10 +258,15 @@ func (s *Service) calculateTestStatus(tr v1alpha1.TestResult, maxRetries, count
 			return v1alpha1.TestRunning
 		case v1.PodFailed:
 			anyFailed = true
+		case v1.PodSucceeded:
+			anySucceeded = true
 		case v1.PodUnknown:
 			return v1alpha1.TestRunning
 		}
 	}
+	if anyFailed && anySucceeded {
+		return v1alpha1.TestFlaky
+	}
 	if anyFailed {
 		return v1alpha1.TestFailed
 	}
@@ -115,11 +274,12 @@ func (s *Service) calculateTestStatus(tr v1alpha1.TestResult, maxRetries, count
 
 }
 
-func (s *Service) adjustSuiteCondition(stat v1alpha1.TestSuiteStatus) v1alpha1.TestSuiteStatus {
+func (s *Service) adjustSuiteCondition(stat v1alpha1.TestSuiteStatus, spec v1alpha1.TestSuiteSpec) v1alpha1.TestSuiteStatus {
 	prevCond := s.getSuiteCondition(stat)
 
-	// TODO(aszecowka)(later) anySkipped, https://github.com/kyma-incubator/octopus/issues/10
-	var anyNotScheduled, anyScheduled, anyRunning, anyUnknown, anyFailed bool
+	setupFailed := s.isSetupFailed(stat)
+	var anyNotScheduled, anyScheduled, anyRunning, anyUnknown, anyFailed, anyFlakyFailed bool
+	var flaky []string
 	var newCond v1alpha1.TestSuiteConditionType
 	for _, res := range stat.Results {
 		switch res.Status {
@@ -136,12 +296,19 @@ func (s *Service) adjustSuiteCondition(stat v1alpha1.TestSuiteStatus) v1alpha1.T
 
 		case v1alpha1.TestFailed:
 			anyFailed = true
+
+		case v1alpha1.TestFlaky:
+			flaky = append(flaky, fmt.Sprintf("%s/%s", res.Namespace, res.Name))
+			anyFlakyFailed = anyFlakyFailed || spec.IsTestFailed(res.Status)
+
+		case v1alpha1.TestSkipped:
+			// skipped tests do not influence the suite result
 		}
 	}
 
 	if anyRunning || anyNotScheduled || anyScheduled {
 		newCond = v1alpha1.SuiteRunning
-	} else if anyFailed {
+	} else if anyFailed || anyFlakyFailed || setupFailed {
 		newCond = v1alpha1.SuiteFailed
 	} else if anyUnknown {
 		newCond = v1alpha1.SuiteError //TODO(aszecowka) later, should it be a error?
@@ -149,10 +316,27 @@ func (s *Service) adjustSuiteCondition(stat v1alpha1.TestSuiteStatus) v1alpha1.T
 		newCond = v1alpha1.SuiteSucceeded
 	}
 
+	// tests with testing pods stuck in Pending failed because of configuration problems, like a missing test image
+	stuck := s.getStuckFailedTests(stat, spec)
+	if newCond == v1alpha1.SuiteFailed && len(stuck) > 0 && !setupFailed {
+		newCond = v1alpha1.SuiteError
+	}
+
 	if newCond == prevCond {
 		return stat
 	}
-	s.SetSuiteCondition(&stat, newCond, "", "")
+	reason, msg := "", ""
+	if newCond == v1alpha1.SuiteError && len(stuck) > 0 {
+		reason = v1alpha1.ReasonPodsStuckPending
+		msg = s.stuckPendingMessage(stuck)
+	} else if newCond == v1alpha1.SuiteFailed && setupFailed {
+		reason = v1alpha1.ReasonSetupFailed
+		msg = s.setupFailedMessage(*stat.Setup)
+	} else if (newCond == v1alpha1.SuiteSucceeded || newCond == v1alpha1.SuiteFailed) && len(flaky) > 0 && !anyFailed {
+		reason = v1alpha1.ReasonFlakyTests
+		msg = fmt.Sprintf("Flaky tests: [%s]", strings.Join(flaky, ", "))
+	}
+	s.SetSuiteCondition(&stat, newCond, reason, msg)
 	switch newCond {
 	case v1alpha1.SuiteFailed:
 		fallthrough
@@ -165,25 +349,53 @@ func (s *Service) adjustSuiteCondition(stat v1alpha1.TestSuiteStatus) v1alpha1.T
 	return stat
 }
 
-func (s *Service) InitializeTests(suite v1alpha1.ClusterTestSuite, defs []v1alpha1.TestDefinition) (*v1alpha1.TestSuiteStatus, error) {
-	out := suite.Status.DeepCopy()
+func (s *Service) InitializeTests(suite v1alpha1.GenericTestSuite, defs []v1alpha1.TestDefinition) (*v1alpha1.TestSuiteStatus, error) {
+	out := suite.GetStatus().DeepCopy()
 	out.StartTime = &metav1.Time{Time: s.nowProvider()}
+	defs = s.withoutHooks(defs, *suite.GetSpec())
 	if len(defs) == 0 {
 		out.CompletionTime = &metav1.Time{Time: s.nowProvider()}
 		s.SetSuiteCondition(out, v1alpha1.SuiteSucceeded, "", "")
 		return out, nil
 	}
+	if err := validateDependencies(defs); err != nil {
+		return nil, err
+	}
 	s.SetSuiteCondition(out, v1alpha1.SuiteRunning, "", "")
-	out.Results = make([]v1alpha1.TestResult, len(defs))
-	for idx, def := range defs {
-		out.Results[idx] = v1alpha1.TestResult{
+	out.Results = make([]v1alpha1.TestResult, 0, len(defs))
+	for _, def := range defs {
+		testStatus := v1alpha1.TestNotYetScheduled
+		if def.Spec.Skip {
+			testStatus = v1alpha1.TestSkipped
+		}
+		tr := v1alpha1.TestResult{
 			Name:                def.Name,
 			Namespace:           def.Namespace,
-			Status:              v1alpha1.TestNotYetScheduled,
+			Status:              testStatus,
 			Executions:          make([]v1alpha1.TestExecution, 0),
 			DisabledConcurrency: def.Spec.DisableConcurrency,
+			ConcurrencyGroup:    def.Spec.ConcurrencyGroup,
+			Timeout:             def.Spec.Timeout,
+			DependsOn:           append([]v1alpha1.TestDefReference(nil), def.Spec.DependsOn...),
+		}
+		if len(def.Spec.Matrix) == 0 {
+			out.Results = append(out.Results, tr)
+			continue
+		}
+		// every variant from the matrix is a separate test
+		for _, variant := range def.Spec.Matrix {
+			vtr := *tr.DeepCopy()
+			vtr.Variant = variant.Name
+			for k, v := range variant.Parameters {
+				if vtr.Parameters == nil {
+					vtr.Parameters = make(map[string]string, len(variant.Parameters))
+				}
+				vtr.Parameters[k] = v
+			}
+			out.Results = append(out.Results, vtr)
 		}
 	}
+	s.skipTestsWithUnsuccessfulDependencies(out, *suite.GetSpec())
 
 	return out, nil
 }
@@ -218,17 +430,17 @@ func (s *Service) SetSuiteCondition(stat *v1alpha1.TestSuiteStatus, tp v1alpha1.
 	})
 }
 
-func (s *Service) IsUninitialized(suite v1alpha1.ClusterTestSuite) bool {
-	if len(suite.Status.Conditions) == 0 {
+func (s *Service) IsUninitialized(suite v1alpha1.GenericTestSuite) bool {
+	if len(suite.GetStatus().Conditions) == 0 {
 		return true
 	}
 
-	if s.isConditionSet(suite.Status, v1alpha1.SuiteUninitialized) {
+	if s.isConditionSet(*suite.GetStatus(), v1alpha1.SuiteUninitialized) {
 		return true
 	}
 
 	// if error occurred on initialization we treat suite as Uninitialized
-	for _, cond := range suite.Status.Conditions {
+	for _, cond := range suite.GetStatus().Conditions {
 		if cond.Type == v1alpha1.SuiteError && cond.Status == v1alpha1.StatusTrue && cond.Reason == v1alpha1.ReasonErrorOnInitialization {
 			return true
 		}
@@ -236,10 +448,18 @@ func (s *Service) IsUninitialized(suite v1alpha1.ClusterTestSuite) bool {
 	return false
 }
 
-func (s *Service) IsFinished(suite v1alpha1.ClusterTestSuite) bool {
-	return s.isConditionSet(suite.Status, v1alpha1.SuiteError) ||
-		s.isConditionSet(suite.Status, v1alpha1.SuiteFailed) ||
-		s.isConditionSet(suite.Status, v1alpha1.SuiteSucceeded)
+// IsFinished returns true if the suite has its final condition and the teardown, if defined, is finished
+func (s *Service) IsFinished(suite v1alpha1.GenericTestSuite) bool {
+	stat := *suite.GetStatus()
+	return s.isTestingFinished(stat) && (stat.Teardown == nil || s.isHookFinished(*stat.Teardown))
+}
+
+// isTestingFinished returns true if all tests are finished and the suite has its final condition
+func (s *Service) isTestingFinished(stat v1alpha1.TestSuiteStatus) bool {
+	return s.isConditionSet(stat, v1alpha1.SuiteError) ||
+		s.isConditionSet(stat, v1alpha1.SuiteFailed) ||
+		s.isConditionSet(stat, v1alpha1.SuiteSucceeded) ||
+		s.isConditionSet(stat, v1alpha1.SuiteAborted)
 }
 
 func (s *Service) isConditionSet(stat v1alpha1.TestSuiteStatus, tp v1alpha1.TestSuiteConditionType) bool {
@@ -260,9 +480,9 @@ func (s *Service) getSuiteCondition(stat v1alpha1.TestSuiteStatus) v1alpha1.Test
 	return v1alpha1.SuiteUninitialized
 }
 
-func (s *Service) GetExecutionsInProgress(suite v1alpha1.ClusterTestSuite) []v1alpha1.TestExecution {
+func (s *Service) GetExecutionsInProgress(suite v1alpha1.GenericTestSuite) []v1alpha1.TestExecution {
 	out := make([]v1alpha1.TestExecution, 0)
-	for _, tr := range suite.Status.Results {
+	for _, tr := range suite.GetStatus().Results {
 		for _, ex := range tr.Executions {
 			if ex.PodPhase == v1.PodPending || ex.PodPhase == v1.PodRunning {
 				out = append(out, ex)
@@ -272,11 +492,12 @@ func (s *Service) GetExecutionsInProgress(suite v1alpha1.ClusterTestSuite) []v1a
 	return out
 }
 
-func (s *Service) MarkAsScheduled(status v1alpha1.TestSuiteStatus, testName, testNs, podName string) (v1alpha1.TestSuiteStatus, error) {
-	for idx, tr := range status.Results {
-		if tr.Name == testName && tr.Namespace == testNs {
-			status.Results[idx].Status = v1alpha1.TestScheduled
-			status.Results[idx].Executions = append(status.Results[idx].Executions, v1alpha1.TestExecution{
+func (s *Service) MarkAsScheduled(status v1alpha1.TestSuiteStatus, testName, testNs, variant, podName string) (v1alpha1.TestSuiteStatus, error) {
+	status = *status.DeepCopy()
+	for _, tr := range status.GetAllResults() {
+		if tr.IsVariantOf(testName, testNs, variant) {
+			tr.Status = v1alpha1.TestScheduled
+			tr.Executions = append(tr.Executions, v1alpha1.TestExecution{
 				ID:        podName,
 				StartTime: &metav1.Time{Time: s.nowProvider()},
 			})
@@ -284,5 +505,5 @@ func (s *Service) MarkAsScheduled(status v1alpha1.TestSuiteStatus, testName, tes
 			return status, nil
 		}
 	}
-	return v1alpha1.TestSuiteStatus{}, fmt.Errorf("cannot mark test as a scheduled [testName: %s, testNs: %s, podName: %s]", testName, testNs, podName)
+	return v1alpha1.TestSuiteStatus{}, fmt.Errorf("cannot mark test as a scheduled [testName: %s, testNs: %s, variant: %s, podName: %s]", testName, testNs, variant, podName)
 }
@@ -2,10 +2,12 @@ package status_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/humanerr"
 	"github.com/kyma-incubator/octopus/pkg/status"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,7 +19,7 @@ func TestIsUninitialized(t *testing.T) {
 	sut := status.Service{}
 
 	t.Run("return true when suite has empty status", func(t *testing.T) {
-		assert.True(t, sut.IsUninitialized(v1alpha1.ClusterTestSuite{}))
+		assert.True(t, sut.IsUninitialized(&v1alpha1.ClusterTestSuite{}))
 	})
 
 	t.Run("return true when uninitialized set explicitly", func(t *testing.T) {
@@ -33,7 +35,7 @@ func TestIsUninitialized(t *testing.T) {
 			},
 		}
 		// WHEN & THEN
-		assert.True(t, sut.IsUninitialized(given))
+		assert.True(t, sut.IsUninitialized(&given))
 	})
 
 	for _, tp := range []v1alpha1.TestSuiteConditionType{v1alpha1.SuiteSucceeded, v1alpha1.SuiteRunning, v1alpha1.SuiteFailed} {
@@ -50,7 +52,7 @@ func TestIsUninitialized(t *testing.T) {
 				},
 			}
 			// WHEN & THEN
-			assert.False(t, sut.IsUninitialized(given))
+			assert.False(t, sut.IsUninitialized(&given))
 		})
 	}
 
@@ -68,7 +70,7 @@ func TestIsUninitialized(t *testing.T) {
 			},
 		}
 		// WHEN & THEN
-		assert.True(t, sut.IsUninitialized(given))
+		assert.True(t, sut.IsUninitialized(&given))
 	})
 
 	t.Run("suite is initialized if is in error state and error occurred after initialization", func(t *testing.T) {
@@ -85,7 +87,7 @@ func TestIsUninitialized(t *testing.T) {
 			},
 		}
 		// WHEN & THEN
-		assert.False(t, sut.IsUninitialized(given))
+		assert.False(t, sut.IsUninitialized(&given))
 
 	})
 }
@@ -94,7 +96,7 @@ func TestIsFinished(t *testing.T) {
 	sut := status.Service{}
 	t.Run("is not finished when no conditions", func(t *testing.T) {
 		givenSuite := v1alpha1.ClusterTestSuite{}
-		assert.False(t, sut.IsFinished(givenSuite))
+		assert.False(t, sut.IsFinished(&givenSuite))
 	})
 
 	t.Run("is finished when error", func(t *testing.T) {
@@ -108,7 +110,7 @@ func TestIsFinished(t *testing.T) {
 				},
 			},
 		}
-		assert.True(t, sut.IsFinished(givenSuite))
+		assert.True(t, sut.IsFinished(&givenSuite))
 	})
 
 	t.Run("is finished when failed", func(t *testing.T) {
@@ -122,7 +124,7 @@ func TestIsFinished(t *testing.T) {
 				},
 			},
 		}
-		assert.True(t, sut.IsFinished(givenSuite))
+		assert.True(t, sut.IsFinished(&givenSuite))
 	})
 
 	t.Run("is finished when succeeded", func(t *testing.T) {
@@ -136,7 +138,7 @@ func TestIsFinished(t *testing.T) {
 				},
 			},
 		}
-		assert.True(t, sut.IsFinished(givenSuite))
+		assert.True(t, sut.IsFinished(&givenSuite))
 	})
 
 	t.Run("is not finished when running", func(t *testing.T) {
@@ -150,7 +152,7 @@ func TestIsFinished(t *testing.T) {
 				},
 			},
 		}
-		assert.False(t, sut.IsFinished(givenSuite))
+		assert.False(t, sut.IsFinished(&givenSuite))
 	})
 }
 
@@ -158,10 +160,10 @@ func TestInitialize(t *testing.T) {
 
 	t.Run("when tests not found", func(t *testing.T) {
 		// GIVEN
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		givenSuite := v1alpha1.ClusterTestSuite{}
 		// WHEN
-		actualStatus, err := sut.InitializeTests(givenSuite, nil)
+		actualStatus, err := sut.InitializeTests(&givenSuite, nil)
 		// THEN
 		require.NoError(t, err)
 		require.NotNil(t, actualStatus)
@@ -175,15 +177,51 @@ func TestInitialize(t *testing.T) {
 		assert.Equal(t, actualStatus.Conditions[0].Status, v1alpha1.StatusTrue)
 	})
 
+	t.Run("when some tests are skipped", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		givenSuite := v1alpha1.ClusterTestSuite{}
+		givenTests := []v1alpha1.TestDefinition{
+			{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test-1",
+					Namespace: "ns-1",
+				},
+				Spec: v1alpha1.TestDefinitionSpec{
+					Skip: true,
+				},
+			},
+			{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test-2",
+					Namespace: "ns-2",
+				},
+			},
+		}
+		// WHEN
+		actualStatus, err := sut.InitializeTests(&givenSuite, givenTests)
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, actualStatus)
+		require.Len(t, actualStatus.Results, 2)
+		assert.Equal(t, v1alpha1.TestSkipped, actualStatus.Results[0].Status)
+		assert.Empty(t, actualStatus.Results[0].Executions)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, actualStatus.Results[1].Status)
+	})
+
 	t.Run("when some tests found", func(t *testing.T) {
 		// GIVEN
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		givenSuite := v1alpha1.ClusterTestSuite{}
 		givenTests := []v1alpha1.TestDefinition{
 			{
 				ObjectMeta: v1.ObjectMeta{
 					Name:      "test-1",
 					Namespace: "ns-1"},
+				Spec: v1alpha1.TestDefinitionSpec{
+					Timeout:          &v1.Duration{Duration: time.Minute},
+					ConcurrencyGroup: "database",
+				},
 			},
 			{
 				ObjectMeta: v1.ObjectMeta{
@@ -193,7 +231,7 @@ func TestInitialize(t *testing.T) {
 			},
 		}
 		// WHEN
-		actualStatus, err := sut.InitializeTests(givenSuite, givenTests)
+		actualStatus, err := sut.InitializeTests(&givenSuite, givenTests)
 		// THEN
 		require.NoError(t, err)
 		require.NotNil(t, actualStatus)
@@ -206,9 +244,182 @@ func TestInitialize(t *testing.T) {
 		assert.Equal(t, "test-1", actualStatus.Results[0].Name)
 		assert.Equal(t, "ns-1", actualStatus.Results[0].Namespace)
 		assert.Equal(t, v1alpha1.TestNotYetScheduled, actualStatus.Results[0].Status)
+		assert.Equal(t, &v1.Duration{Duration: time.Minute}, actualStatus.Results[0].Timeout)
+		assert.Equal(t, "database", actualStatus.Results[0].ConcurrencyGroup)
 		assert.Equal(t, "test-2", actualStatus.Results[1].Name)
 		assert.Equal(t, "ns-2", actualStatus.Results[1].Namespace)
 		assert.Equal(t, v1alpha1.TestNotYetScheduled, actualStatus.Results[1].Status)
+		assert.Nil(t, actualStatus.Results[1].Timeout)
+	})
+}
+
+func TestInitializeWithDependencies(t *testing.T) {
+	givenDef := func(name string, skip bool, deps ...string) v1alpha1.TestDefinition {
+		def := v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1alpha1.TestDefinitionSpec{Skip: skip},
+		}
+		for _, dep := range deps {
+			def.Spec.DependsOn = append(def.Spec.DependsOn, v1alpha1.TestDefReference{Name: dep, Namespace: "default"})
+		}
+		return def
+	}
+
+	t.Run("dependents of skipped tests are skipped", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		givenSuite := v1alpha1.ClusterTestSuite{}
+		// WHEN
+		actualStatus, err := sut.InitializeTests(&givenSuite, []v1alpha1.TestDefinition{
+			givenDef("restore", false, "backup"),
+			givenDef("backup", false, "install"),
+			givenDef("install", true),
+			givenDef("other", false),
+		})
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, actualStatus.Results, 4)
+		assert.Equal(t, v1alpha1.TestSkipped, actualStatus.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestReasonDependencyNotSucceeded, actualStatus.Results[0].Reason)
+		assert.Equal(t, "Test [name: backup, namespace: default] that this test depends on has status [Skipped]", actualStatus.Results[0].Message)
+		assert.Equal(t, []v1alpha1.TestDefReference{{Name: "backup", Namespace: "default"}}, actualStatus.Results[0].DependsOn)
+		assert.Equal(t, v1alpha1.TestSkipped, actualStatus.Results[1].Status)
+		assert.Equal(t, v1alpha1.TestReasonDependencyNotSucceeded, actualStatus.Results[1].Reason)
+		assert.Equal(t, v1alpha1.TestSkipped, actualStatus.Results[2].Status)
+		assert.Empty(t, actualStatus.Results[2].Reason)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, actualStatus.Results[3].Status)
+	})
+
+	t.Run("returns error on cycle", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		givenSuite := v1alpha1.ClusterTestSuite{}
+		// WHEN
+		_, err := sut.InitializeTests(&givenSuite, []v1alpha1.TestDefinition{
+			givenDef("other", false),
+			givenDef("install", false, "restore"),
+			givenDef("backup", false, "install"),
+			givenDef("restore", false, "other", "backup"),
+		})
+		// THEN
+		require.Error(t, err)
+		hErr, ok := humanerr.GetHumanReadableError(err)
+		require.True(t, ok)
+		assert.Equal(t, "Dependencies between Test Definitions form a cycle: default/install -> default/restore -> default/backup -> default/install", hErr.Message)
+	})
+
+	t.Run("returns error on dependency on itself", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		givenSuite := v1alpha1.ClusterTestSuite{}
+		// WHEN
+		_, err := sut.InitializeTests(&givenSuite, []v1alpha1.TestDefinition{givenDef("install", false, "install")})
+		// THEN
+		hErr, ok := humanerr.GetHumanReadableError(err)
+		require.True(t, ok)
+		assert.Equal(t, "Dependencies between Test Definitions form a cycle: default/install -> default/install", hErr.Message)
+	})
+
+	t.Run("returns error on dependency not selected by suite", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		givenSuite := v1alpha1.ClusterTestSuite{}
+		// WHEN
+		_, err := sut.InitializeTests(&givenSuite, []v1alpha1.TestDefinition{givenDef("backup", false, "install")})
+		// THEN
+		hErr, ok := humanerr.GetHumanReadableError(err)
+		require.True(t, ok)
+		assert.Equal(t, "Test Definition [name: backup, namespace: default] depends on Test Definition [name: install, namespace: default] which is not selected by the suite", hErr.Message)
+	})
+}
+
+func TestEnsureStatusIsUpToDateSkipsDependentsOfFailedTests(t *testing.T) {
+	// GIVEN
+	sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+	suite := v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+		Status: v1alpha1.TestSuiteStatus{
+			Conditions: conditionSuiteRunning(),
+			Results: []v1alpha1.TestResult{
+				{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+					{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning},
+				}},
+				{Name: "test-b", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, DependsOn: []v1alpha1.TestDefReference{{Name: "test-a", Namespace: "default"}}},
+			},
+		},
+	}
+	// WHEN
+	stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+		getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodFailed}),
+	})
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.TestFailed, stat.Results[0].Status)
+	assert.Equal(t, v1alpha1.TestSkipped, stat.Results[1].Status)
+	assert.Equal(t, v1alpha1.TestReasonDependencyNotSucceeded, stat.Results[1].Reason)
+	assert.Equal(t, "Test [name: test-a, namespace: default] that this test depends on has status [Failed]", stat.Results[1].Message)
+	assert.Equal(t, v1alpha1.SuiteFailed, stat.Conditions[1].Type)
+}
+
+func TestInitializeWithMatrix(t *testing.T) {
+	t.Run("every variant is a separate test", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		givenSuite := v1alpha1.ClusterTestSuite{}
+		matrixDef := v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: "test-a", Namespace: "default"},
+			Spec: v1alpha1.TestDefinitionSpec{
+				ConcurrencyGroup: "database",
+				Matrix: []v1alpha1.TestVariant{
+					{Name: "mysql", Parameters: map[string]string{"DB_HOST": "mysql"}},
+					{Name: "postgres", Parameters: map[string]string{"DB_HOST": "postgres"}},
+				},
+			},
+		}
+		plainDef := v1alpha1.TestDefinition{ObjectMeta: v1.ObjectMeta{Name: "test-b", Namespace: "default"}}
+		// WHEN
+		actualStatus, err := sut.InitializeTests(&givenSuite, []v1alpha1.TestDefinition{matrixDef, plainDef})
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, actualStatus.Results, 3)
+		assert.Equal(t, "test-a", actualStatus.Results[0].Name)
+		assert.Equal(t, "mysql", actualStatus.Results[0].Variant)
+		assert.Equal(t, map[string]string{"DB_HOST": "mysql"}, actualStatus.Results[0].Parameters)
+		assert.Equal(t, "database", actualStatus.Results[0].ConcurrencyGroup)
+		assert.Equal(t, "test-a", actualStatus.Results[1].Name)
+		assert.Equal(t, "postgres", actualStatus.Results[1].Variant)
+		assert.Equal(t, map[string]string{"DB_HOST": "postgres"}, actualStatus.Results[1].Parameters)
+		assert.Equal(t, "test-b", actualStatus.Results[2].Name)
+		assert.Empty(t, actualStatus.Results[2].Variant)
+		assert.Nil(t, actualStatus.Results[2].Parameters)
+	})
+
+	t.Run("dependents are skipped if any variant failed", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Variant: "mysql", Status: v1alpha1.TestSucceeded, Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-a-mysql-0", PodPhase: v12.PodSucceeded},
+					}},
+					{Name: "test-a", Namespace: "default", Variant: "postgres", Status: v1alpha1.TestFailed, Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-a-postgres-0", PodPhase: v12.PodFailed},
+					}},
+					{Name: "test-b", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, DependsOn: []v1alpha1.TestDefReference{{Name: "test-a", Namespace: "default"}}},
+				},
+			},
+		}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, nil)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[2].Status)
+		assert.Equal(t, "Test [name: test-a, namespace: default] that this test depends on has status [Failed]", stat.Results[2].Message)
 	})
 }
 
@@ -272,7 +483,7 @@ func TestSetSuiteCondition(t *testing.T) {
 func TestEnsureStatusIsUpToDate(t *testing.T) {
 	t.Run("when no tests found and suite is already finished", func(t *testing.T) {
 		// GIVEN
-		sut := status.NewService(nil)
+		sut := status.NewService(nil, &fakeRecorder{})
 		// WHEN
 		suite := v1alpha1.ClusterTestSuite{
 			Status: v1alpha1.TestSuiteStatus{
@@ -287,7 +498,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		noChangesExpected := suite.Status
-		stat, err := sut.EnsureStatusIsUpToDate(suite, nil)
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, nil)
 		// THEN
 		require.NoError(t, err)
 		require.NotNil(t, stat)
@@ -296,7 +507,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 
 	t.Run("when pods not yet started", func(t *testing.T) {
 		// GIVEN
-		sut := status.NewService(nil)
+		sut := status.NewService(nil, &fakeRecorder{})
 		// WHEN
 		suite := v1alpha1.ClusterTestSuite{
 			Status: v1alpha1.TestSuiteStatus{
@@ -311,7 +522,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		noChangesExpected := suite.Status
-		stat, err := sut.EnsureStatusIsUpToDate(suite, nil)
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, nil)
 		// THEN
 		require.NoError(t, err)
 		require.NotNil(t, stat)
@@ -320,7 +531,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 
 	t.Run("when first pod is running its phase is updated", func(t *testing.T) {
 		// GIVEN
-		sut := status.NewService(nil)
+		sut := status.NewService(nil, &fakeRecorder{})
 		suite := v1alpha1.ClusterTestSuite{
 			ObjectMeta: v1.ObjectMeta{
 				Name: "test-all",
@@ -343,7 +554,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		// WHEN
-		stat, err := sut.EnsureStatusIsUpToDate(suite, []v12.Pod{
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
 			getTestPodAInStatus(0,
 				v12.PodStatus{
 					Phase: v12.PodRunning,
@@ -372,7 +583,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 	})
 
 	t.Run("when some pods are running and some are already failed", func(t *testing.T) {
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		suite := v1alpha1.ClusterTestSuite{
 			ObjectMeta: v1.ObjectMeta{
 				Name: "test-all",
@@ -408,7 +619,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		// WHEN
-		stat, err := sut.EnsureStatusIsUpToDate(suite, []v12.Pod{
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
 			getTestPodAInStatus(0, v12.PodStatus{
 				Phase: v12.PodRunning,
 			}),
@@ -457,7 +668,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 	})
 
 	t.Run("when all pods finished successfully", func(t *testing.T) {
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		suite := v1alpha1.ClusterTestSuite{
 			ObjectMeta: v1.ObjectMeta{
 				Name: "test-all",
@@ -493,7 +704,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		// WHEN
-		stat, err := sut.EnsureStatusIsUpToDate(suite, []v12.Pod{
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
 			getTestPodAInStatus(0, v12.PodStatus{
 				Phase: v12.PodSucceeded,
 			}),
@@ -549,7 +760,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 	})
 
 	t.Run("when all pods finished, one in failed state", func(t *testing.T) {
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		suite := v1alpha1.ClusterTestSuite{
 			ObjectMeta: v1.ObjectMeta{
 				Name: "test-all",
@@ -585,7 +796,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		// WHEN
-		stat, err := sut.EnsureStatusIsUpToDate(suite, []v12.Pod{
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
 			getTestPodAInStatus(0, v12.PodStatus{
 				Phase: v12.PodSucceeded,
 			}),
@@ -644,7 +855,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 	})
 
 	t.Run("suite is running if all already scheduled pods are finished but new one are not created yet", func(t *testing.T) {
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		suite := v1alpha1.ClusterTestSuite{
 			ObjectMeta: v1.ObjectMeta{
 				Name: "test-all",
@@ -671,7 +882,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		// WHEN
-		stat, err := sut.EnsureStatusIsUpToDate(suite, []v12.Pod{
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
 			getTestPodAInStatus(0, v12.PodStatus{
 				Phase: v12.PodSucceeded,
 			}),
@@ -701,7 +912,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 
 	t.Run("maxRetries: suite is succeeded if tests finally pass", func(t *testing.T) {
 		// GIVEN
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		suite := v1alpha1.ClusterTestSuite{
 			Spec: specWithRetries(3),
 			Status: v1alpha1.TestSuiteStatus{
@@ -734,7 +945,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		// WHEN
-		stat, err := sut.EnsureStatusIsUpToDate(suite, []v12.Pod{
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
 			getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodFailed}),
 			getTestPodAInStatus(1, v12.PodStatus{Phase: v12.PodFailed}),
 			getTestPodAInStatus(2, v12.PodStatus{Phase: v12.PodFailed}),
@@ -749,16 +960,18 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 				Status: v1alpha1.StatusFalse,
 			},
 			{
-				Type:   v1alpha1.SuiteSucceeded,
-				Status: v1alpha1.StatusTrue,
+				Type:    v1alpha1.SuiteSucceeded,
+				Status:  v1alpha1.StatusTrue,
+				Reason:  v1alpha1.ReasonFlakyTests,
+				Message: "Flaky tests: [default/test-a]",
 			},
 		}, stat.Conditions)
-
+		assert.Equal(t, v1alpha1.TestFlaky, stat.Results[0].Status)
 	})
 
 	t.Run("maxRetries: suite is running if pods are not finished", func(t *testing.T) {
 		// GIVEN
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		suite := v1alpha1.ClusterTestSuite{
 			Spec: specWithRetries(3),
 			Status: v1alpha1.TestSuiteStatus{
@@ -783,7 +996,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		// WHEN
-		stat, err := sut.EnsureStatusIsUpToDate(suite, []v12.Pod{
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
 			getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodFailed}),
 			getTestPodAInStatus(1, v12.PodStatus{Phase: v12.PodRunning}),
 		})
@@ -801,7 +1014,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 
 	t.Run("maxRetries: suite is running if all test failed but maxRetries not reached", func(t *testing.T) {
 		// GIVEN
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		suite := v1alpha1.ClusterTestSuite{
 			Spec: specWithRetries(3),
 			Status: v1alpha1.TestSuiteStatus{
@@ -822,7 +1035,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		// WHEN
-		stat, err := sut.EnsureStatusIsUpToDate(suite, []v12.Pod{
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
 			getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodFailed}),
 		})
 		// THEN
@@ -839,7 +1052,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 
 	t.Run("maxRetries: suite is failed if test failed maximum number of times", func(t *testing.T) {
 		// GIVEN
-		sut := status.NewService(mockNowProvider())
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
 		suite := v1alpha1.ClusterTestSuite{
 			Spec: specWithRetries(3),
 			Status: v1alpha1.TestSuiteStatus{
@@ -872,7 +1085,7 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 			},
 		}
 		// WHEN
-		stat, err := sut.EnsureStatusIsUpToDate(suite, []v12.Pod{
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
 			getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodFailed}),
 			getTestPodAInStatus(1, v12.PodStatus{Phase: v12.PodFailed}),
 			getTestPodAInStatus(2, v12.PodStatus{Phase: v12.PodFailed}),
@@ -896,116 +1109,1486 @@ func TestEnsureStatusIsUpToDate(t *testing.T) {
 
 }
 
-func specWithRetries(retries int64) v1alpha1.TestSuiteSpec {
-	return v1alpha1.TestSuiteSpec{
-		MaxRetries: retries,
-	}
-}
-
-func conditionSuiteRunning() []v1alpha1.TestSuiteCondition {
-	return []v1alpha1.TestSuiteCondition{
-		{
-			Type:   v1alpha1.SuiteRunning,
-			Status: v1alpha1.StatusTrue,
-		},
-	}
-}
-
-func TestMarkAsScheduled(t *testing.T) {
-	// GIVEN
-	sut := status.NewService(mockNowProvider())
-	// WHEN
-	actStatus, err := sut.MarkAsScheduled(v1alpha1.TestSuiteStatus{
-		Conditions: []v1alpha1.TestSuiteCondition{
-			{
-				Type: v1alpha1.SuiteRunning,
+func TestEnsureStatusIsUpToDateWithTestTimeout(t *testing.T) {
+	t.Run("execution that exceeded timeout is marked as failed", func(t *testing.T) {
+		// GIVEN
+		recorder := &fakeRecorder{}
+		sut := status.NewService(mockNowProvider(), recorder)
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "test-all",
 			},
-		},
-		StartTime: &v1.Time{Time: getTimeInPast()},
-		Results: []v1alpha1.TestResult{
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Results: []v1alpha1.TestResult{
+					{
+						Name:      "test-a",
+						Namespace: "default",
+						Status:    v1alpha1.TestRunning,
+						Timeout:   &v1.Duration{Duration: time.Minute},
+						Executions: []v1alpha1.TestExecution{
+							{
+								ID:        getPodNameForTestA(0),
+								StartTime: &v1.Time{Time: getStartTime().Add(-2 * time.Minute)},
+								PodPhase:  v12.PodRunning,
+							},
+						},
+					},
+				},
+			},
+		}
+		// WHEN
+		stat, pending, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+			getTestPodAInStatus(0, v12.PodStatus{
+				Phase: v12.PodRunning,
+			}),
+		})
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, stat)
+		assert.Equal(t, []v1alpha1.TestResult{
 			{
 				Name:      "test-a",
 				Namespace: "default",
-				Status:    v1alpha1.TestNotYetScheduled,
+				Status:    v1alpha1.TestFailed,
+				Timeout:   &v1.Duration{Duration: time.Minute},
+				Executions: []v1alpha1.TestExecution{
+					{
+						ID:             getPodNameForTestA(0),
+						PodPhase:       v12.PodFailed,
+						StartTime:      &v1.Time{Time: getStartTime().Add(-2 * time.Minute)},
+						CompletionTime: &v1.Time{Time: getStartTime()},
+						Reason:         v1alpha1.ExecutionReasonTimedOut,
+						Message:        "Test execution exceeded timeout [1m0s]",
+					},
+				},
 			},
-		},
-	}, "test-a", "default", getPodNameForTestA(0))
-	// THEN
-	require.NoError(t, err)
-	require.Equal(t, v1alpha1.TestSuiteStatus{
-		Conditions: []v1alpha1.TestSuiteCondition{
+		}, stat.Results)
+		// events are recorded only once the status is persisted
+		assert.Empty(t, recorder.events)
+		pending.Record()
+		assert.Equal(t, []string{"Warning ExecutionTimedOut Execution [oct-tp-test-all-test-a-0] of test [name: test-a, namespace: default] exceeded timeout [1m0s]"}, recorder.events)
+		assert.Equal(t, []string{"default/test-a"}, recorder.failedTests)
+		assert.Equal(t, []v1alpha1.TestSuiteCondition{
 			{
-				Type: v1alpha1.SuiteRunning,
+				Type:   v1alpha1.SuiteRunning,
+				Status: v1alpha1.StatusFalse,
 			},
-		},
-		StartTime: &v1.Time{Time: getTimeInPast()},
-		Results: []v1alpha1.TestResult{
+			{
+				Type:   v1alpha1.SuiteFailed,
+				Status: v1alpha1.StatusTrue,
+			},
+		}, stat.Conditions)
+	})
+
+	t.Run("execution within timeout is not changed", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		givenResults := []v1alpha1.TestResult{
 			{
 				Name:      "test-a",
 				Namespace: "default",
-				Status:    v1alpha1.TestScheduled,
+				Status:    v1alpha1.TestRunning,
+				Timeout:   &v1.Duration{Duration: time.Hour},
 				Executions: []v1alpha1.TestExecution{
 					{
 						ID:        getPodNameForTestA(0),
-						StartTime: &v1.Time{Time: getStartTime()},
+						StartTime: &v1.Time{Time: getStartTime().Add(-2 * time.Minute)},
+						PodPhase:  v12.PodRunning,
 					},
 				},
 			},
-		},
-	}, actStatus)
-}
+		}
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Results:    givenResults,
+			},
+		}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+			getTestPodAInStatus(0, v12.PodStatus{
+				Phase: v12.PodRunning,
+			}),
+		})
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, stat)
+		assert.Equal(t, givenResults, stat.Results)
+		assert.Equal(t, conditionSuiteRunning(), stat.Conditions)
+	})
 
-func TestGetExecutionsInProgress(t *testing.T) {
-	sut := status.NewService(nil)
-	t.Run("returns nil if no tests to run", func(t *testing.T) {
+	t.Run("timed out execution is not overwritten by phase of terminated pod", func(t *testing.T) {
 		// GIVEN
-		suite := v1alpha1.ClusterTestSuite{}
+		sut := status.NewService(nil, &fakeRecorder{})
+		givenResults := []v1alpha1.TestResult{
+			{
+				Name:      "test-a",
+				Namespace: "default",
+				Status:    v1alpha1.TestRunning,
+				Timeout:   &v1.Duration{Duration: time.Minute},
+				Executions: []v1alpha1.TestExecution{
+					{
+						ID:             getPodNameForTestA(0),
+						StartTime:      &v1.Time{Time: getStartTime()},
+						CompletionTime: &v1.Time{Time: getStartTime().Add(time.Minute)},
+						PodPhase:       v12.PodFailed,
+						Reason:         v1alpha1.ExecutionReasonTimedOut,
+					},
+				},
+			},
+		}
+		suite := v1alpha1.ClusterTestSuite{
+			Spec: specWithRetries(1),
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Results:    givenResults,
+			},
+		}
 		// WHEN
-		actual := sut.GetExecutionsInProgress(suite)
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+			getTestPodAInStatus(0, v12.PodStatus{
+				Phase: v12.PodRunning,
+			}),
+		})
 		// THEN
-		require.Empty(t, actual)
+		require.NoError(t, err)
+		require.NotNil(t, stat)
+		assert.Equal(t, givenResults, stat.Results)
+		assert.Equal(t, conditionSuiteRunning(), stat.Conditions)
 	})
-	t.Run("returns only Pending and Running executions", func(t *testing.T) {
+}
+
+func TestEnsureStatusIsUpToDateWithSuiteTimeout(t *testing.T) {
+	t.Run("suite that exceeded timeout is interrupted", func(t *testing.T) {
 		// GIVEN
+		recorder := &fakeRecorder{}
+		sut := status.NewService(mockNowProvider(), recorder)
 		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "test-all",
+			},
+			Spec: v1alpha1.TestSuiteSpec{
+				Count:        2,
+				SuiteTimeout: &v1.Duration{Duration: time.Minute},
+			},
 			Status: v1alpha1.TestSuiteStatus{
+				StartTime:  &v1.Time{Time: getStartTime().Add(-2 * time.Minute)},
+				Conditions: conditionSuiteRunning(),
 				Results: []v1alpha1.TestResult{
 					{
-						Name: "test-1",
+						Name:      "test-a",
+						Namespace: "default",
+						Status:    v1alpha1.TestRunning,
 						Executions: []v1alpha1.TestExecution{
 							{
-								ID:       "id-111",
-								PodPhase: v12.PodSucceeded,
-							},
-							{
-								ID:       "id-112",
-								PodPhase: v12.PodRunning,
-							},
-							{
-								ID:       "id-113",
-								PodPhase: v12.PodFailed,
+								ID:        getPodNameForTestA(0),
+								StartTime: &v1.Time{Time: getStartTime().Add(-time.Minute)},
+								PodPhase:  v12.PodRunning,
 							},
 						},
 					},
 					{
-						Name: "test-2",
-						Executions: []v1alpha1.TestExecution{
-							{
-								ID:       "id-211",
-								PodPhase: v12.PodPending,
-							},
-							{
-								ID:       "id-212",
-								PodPhase: v12.PodFailed,
-							},
-						},
+						Name:       "test-b",
+						Namespace:  "default",
+						Status:     v1alpha1.TestNotYetScheduled,
+						Executions: []v1alpha1.TestExecution{},
 					},
 				},
 			},
 		}
 		// WHEN
-		actual := sut.GetExecutionsInProgress(suite)
-		// THEN
+		stat, pending, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+			getTestPodAInStatus(0, v12.PodStatus{
+				Phase: v12.PodRunning,
+			}),
+		})
+		pending.Record()
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, stat)
+		assert.Equal(t, []v1alpha1.TestResult{
+			{
+				Name:      "test-a",
+				Namespace: "default",
+				Status:    v1alpha1.TestFailed,
+				Executions: []v1alpha1.TestExecution{
+					{
+						ID:             getPodNameForTestA(0),
+						PodPhase:       v12.PodFailed,
+						StartTime:      &v1.Time{Time: getStartTime().Add(-time.Minute)},
+						CompletionTime: &v1.Time{Time: getStartTime()},
+						Reason:         v1alpha1.ExecutionReasonSuiteTimedOut,
+						Message:        "Suite exceeded timeout [1m0s]",
+					},
+				},
+			},
+			{
+				Name:       "test-b",
+				Namespace:  "default",
+				Status:     v1alpha1.TestSkipped,
+				Reason:     v1alpha1.TestReasonSuiteTimedOut,
+				Message:    "Suite exceeded timeout [1m0s]",
+				Executions: []v1alpha1.TestExecution{},
+			},
+		}, stat.Results)
+		assert.Equal(t, []v1alpha1.TestSuiteCondition{
+			{
+				Type:   v1alpha1.SuiteRunning,
+				Status: v1alpha1.StatusFalse,
+			},
+			{
+				Type:    v1alpha1.SuiteError,
+				Status:  v1alpha1.StatusTrue,
+				Reason:  v1alpha1.ReasonSuiteTimeout,
+				Message: "Suite exceeded timeout [1m0s], running tests were interrupted",
+			},
+		}, stat.Conditions)
+		assert.Equal(t, &v1.Time{Time: getStartTime()}, stat.CompletionTime)
+		assert.Equal(t, []string{"Warning SuiteTimedOut Suite exceeded timeout [1m0s], running tests were interrupted"}, recorder.events)
+	})
+
+	t.Run("suite uses default timeout", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		givenResults := []v1alpha1.TestResult{
+			{
+				Name:      "test-a",
+				Namespace: "default",
+				Status:    v1alpha1.TestRunning,
+				Executions: []v1alpha1.TestExecution{
+					{
+						ID:        getPodNameForTestA(0),
+						StartTime: &v1.Time{Time: getStartTime().Add(-time.Minute)},
+						PodPhase:  v12.PodRunning,
+					},
+				},
+			},
+		}
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				StartTime:  &v1.Time{Time: getStartTime().Add(-59 * time.Minute)},
+				Conditions: conditionSuiteRunning(),
+				Results:    givenResults,
+			},
+		}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+			getTestPodAInStatus(0, v12.PodStatus{
+				Phase: v12.PodRunning,
+			}),
+		})
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, stat)
+		assert.Equal(t, givenResults, stat.Results)
+		assert.Equal(t, conditionSuiteRunning(), stat.Conditions)
+		assert.Nil(t, stat.CompletionTime)
+	})
+}
+
+func TestEnsureStatusIsUpToDateWithSkippedTests(t *testing.T) {
+	t.Run("suite with skipped and passed tests is succeeded", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Results: []v1alpha1.TestResult{
+					{
+						Name:      "test-a",
+						Namespace: "default",
+						Status:    v1alpha1.TestRunning,
+						Executions: []v1alpha1.TestExecution{
+							{
+								ID:        getPodNameForTestA(0),
+								StartTime: &v1.Time{Time: getTimeInPast()},
+								PodPhase:  v12.PodRunning,
+							},
+						},
+					},
+					{
+						Name:       "test-b",
+						Namespace:  "default",
+						Status:     v1alpha1.TestSkipped,
+						Executions: []v1alpha1.TestExecution{},
+					},
+				},
+			},
+		}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+			getTestPodAInStatus(0, v12.PodStatus{
+				Phase: v12.PodSucceeded,
+			}),
+		})
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, stat)
+		assert.Equal(t, v1alpha1.TestSucceeded, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[1].Status)
+		assert.Equal(t, []v1alpha1.TestSuiteCondition{
+			{
+				Type:   v1alpha1.SuiteRunning,
+				Status: v1alpha1.StatusFalse,
+			},
+			{
+				Type:   v1alpha1.SuiteSucceeded,
+				Status: v1alpha1.StatusTrue,
+			},
+		}, stat.Conditions)
+	})
+
+	t.Run("suite with only skipped tests is succeeded", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Results: []v1alpha1.TestResult{
+					{
+						Name:       "test-a",
+						Namespace:  "default",
+						Status:     v1alpha1.TestSkipped,
+						Executions: []v1alpha1.TestExecution{},
+					},
+				},
+			},
+		}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, nil)
+		// THEN
+		require.NoError(t, err)
+		require.NotNil(t, stat)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[0].Status)
+		assert.Equal(t, []v1alpha1.TestSuiteCondition{
+			{
+				Type:   v1alpha1.SuiteRunning,
+				Status: v1alpha1.StatusFalse,
+			},
+			{
+				Type:   v1alpha1.SuiteSucceeded,
+				Status: v1alpha1.StatusTrue,
+			},
+		}, stat.Conditions)
+		assert.Equal(t, &v1.Time{Time: getStartTime()}, stat.CompletionTime)
+	})
+}
+
+func TestEnsureStatusIsUpToDateRecordsEvents(t *testing.T) {
+	// GIVEN
+	recorder := &fakeRecorder{}
+	sut := status.NewService(mockNowProvider(), recorder)
+	suite := v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Spec:       specWithRetries(1),
+		Status: v1alpha1.TestSuiteStatus{
+			Conditions: conditionSuiteRunning(),
+			Results: []v1alpha1.TestResult{
+				{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+					{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning},
+				}},
+				{Name: "test-b", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+					{ID: getPodNameForTestB(0), PodPhase: v12.PodRunning},
+				}},
+			},
+		},
+	}
+	// WHEN
+	_, pending, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+		getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodSucceeded}),
+		getTestPodBInStatus(0, v12.PodStatus{Phase: v12.PodFailed, Message: "exit code 1"}),
+	})
+	pending.Record()
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Normal ExecutionSucceeded Execution [oct-tp-test-all-test-a-0] of test [name: test-a, namespace: default] succeeded",
+		"Warning ExecutionFailed Execution [oct-tp-test-all-test-b-0] of test [name: test-b, namespace: default] failed: exit code 1",
+	}, recorder.events)
+	assert.Equal(t, []string{"default/test-b"}, recorder.failedTests)
+}
+
+func TestEnsureStatusIsUpToDateRecordsContainers(t *testing.T) {
+	// GIVEN
+	recorder := &fakeRecorder{}
+	sut := status.NewService(mockNowProvider(), recorder)
+	suite := v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+		Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+		Status: v1alpha1.TestSuiteStatus{
+			Conditions: conditionSuiteRunning(),
+			Results: []v1alpha1.TestResult{
+				{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+					{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning},
+				}},
+			},
+		},
+	}
+	// WHEN
+	stat, pending, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+		getTestPodAInStatus(0, v12.PodStatus{
+			Phase: v12.PodFailed,
+			InitContainerStatuses: []v12.ContainerStatus{
+				{Name: "init", State: v12.ContainerState{Terminated: &v12.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}}},
+			},
+			ContainerStatuses: []v12.ContainerStatus{
+				{Name: "test", RestartCount: 2, State: v12.ContainerState{Terminated: &v12.ContainerStateTerminated{
+					ExitCode: 137, Reason: "OOMKilled", Message: "allocating test data",
+				}}},
+				{Name: "sidecar", State: v12.ContainerState{Running: &v12.ContainerStateRunning{}}},
+			},
+		}),
+	})
+	pending.Record()
+	// THEN
+	require.NoError(t, err)
+	zero, oomKilled := int32(0), int32(137)
+	exec := stat.Results[0].Executions[0]
+	assert.Equal(t, []v1alpha1.ContainerResult{
+		{Name: "init", InitContainer: true, ExitCode: &zero, Reason: "Completed"},
+		{Name: "test", ExitCode: &oomKilled, Reason: "OOMKilled", Message: "allocating test data", RestartCount: 2},
+		{Name: "sidecar"},
+	}, exec.Containers)
+	assert.Equal(t, "Container [test] terminated with exit code [137], reason [OOMKilled]", exec.Message)
+	assert.Equal(t, []string{
+		"Warning ExecutionFailed Execution [oct-tp-test-all-test-a-0] of test [name: test-a, namespace: default] failed: Container [test] terminated with exit code [137], reason [OOMKilled]",
+	}, recorder.events)
+}
+
+func TestEnsureStatusIsUpToDateWithStuckPendingPods(t *testing.T) {
+	givenSuite := func() v1alpha1.ClusterTestSuite {
+		return v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1, PendingTimeout: &v1.Duration{Duration: time.Minute}},
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestScheduled, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestA(0), PodPhase: v12.PodPending},
+					}},
+				},
+			},
+		}
+	}
+	givenPod := func(created time.Time, podStatus v12.PodStatus) v12.Pod {
+		podStatus.Phase = v12.PodPending
+		pod := getTestPodAInStatus(0, podStatus)
+		pod.CreationTimestamp = v1.Time{Time: created}
+		return pod
+	}
+	imagePullBackOff := v12.PodStatus{ContainerStatuses: []v12.ContainerStatus{
+		{Name: "test", Image: "alpine:typo", State: v12.ContainerState{Waiting: &v12.ContainerStateWaiting{
+			Reason: "ImagePullBackOff", Message: "Back-off pulling image",
+		}}},
+	}}
+
+	t.Run("fails execution with image that cannot be pulled and finishes suite with error", func(t *testing.T) {
+		// GIVEN
+		recorder := &fakeRecorder{}
+		sut := status.NewService(mockNowProvider(), recorder)
+		suite := givenSuite()
+		// WHEN
+		stat, pending, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{givenPod(getStartTime().Add(-2*time.Minute), imagePullBackOff)})
+		pending.Record()
+		// THEN
+		require.NoError(t, err)
+		exec := stat.Results[0].Executions[0]
+		assert.Equal(t, v12.PodFailed, exec.PodPhase)
+		assert.Equal(t, v1alpha1.ExecutionReasonStuckPending, exec.Reason)
+		assert.Equal(t, "Cannot pull image [alpine:typo] of container [test]", exec.Message)
+		assert.Equal(t, v1alpha1.TestFailed, stat.Results[0].Status)
+		assert.Contains(t, stat.Conditions, v1alpha1.TestSuiteCondition{
+			Type:    v1alpha1.SuiteError,
+			Status:  v1alpha1.StatusTrue,
+			Reason:  v1alpha1.ReasonPodsStuckPending,
+			Message: "Testing pods of tests [default/test-a] were stuck in Pending",
+		})
+		assert.Equal(t, []string{
+			"Warning ExecutionStuckPending Execution [oct-tp-test-all-test-a-0] of test [name: test-a, namespace: default] was stuck in Pending for longer than [1m0s]: Cannot pull image [alpine:typo] of container [test]",
+		}, recorder.events)
+	})
+
+	t.Run("waits for pending timeout", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite()
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{givenPod(getStartTime().Add(-30*time.Second), imagePullBackOff)})
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, suite.Status.Results[0].Executions, stat.Results[0].Executions)
+		assert.Equal(t, conditionSuiteRunning(), stat.Conditions)
+	})
+
+	t.Run("fails execution with unschedulable pod", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite()
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{givenPod(getStartTime().Add(-2*time.Minute), v12.PodStatus{
+			Conditions: []v12.PodCondition{{
+				Type: v12.PodScheduled, Status: v12.ConditionFalse, Reason: v12.PodReasonUnschedulable, Message: "0/3 nodes are available: 3 Insufficient cpu.",
+			}},
+		})})
+		// THEN
+		require.NoError(t, err)
+		exec := stat.Results[0].Executions[0]
+		assert.Equal(t, v12.PodFailed, exec.PodPhase)
+		assert.Equal(t, "Testing pod cannot be scheduled: 0/3 nodes are available: 3 Insufficient cpu.", exec.Message)
+	})
+
+	t.Run("does not fail pending pod without problems", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite()
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{givenPod(getStartTime().Add(-2*time.Minute), v12.PodStatus{
+			ContainerStatuses: []v12.ContainerStatus{
+				{Name: "test", State: v12.ContainerState{Waiting: &v12.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			},
+		})})
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v12.PodPending, stat.Results[0].Executions[0].PodPhase)
+	})
+}
+
+func TestEnsureStatusIsUpToDateWithLostPods(t *testing.T) {
+	givenSuite := func(exec v1alpha1.TestExecution) v1alpha1.ClusterTestSuite {
+		return v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       specWithRetries(1),
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{exec}},
+				},
+			},
+		}
+	}
+
+	t.Run("fails execution with deleted pod and lets it be retried", func(t *testing.T) {
+		// GIVEN
+		recorder := &fakeRecorder{}
+		sut := status.NewService(mockNowProvider(), recorder)
+		suite := givenSuite(v1alpha1.TestExecution{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning})
+		// WHEN
+		stat, pending, err := sut.EnsureStatusIsUpToDate(&suite, nil)
+		pending.Record()
+		// THEN
+		require.NoError(t, err)
+		exec := stat.Results[0].Executions[0]
+		assert.Equal(t, v12.PodFailed, exec.PodPhase)
+		assert.Equal(t, v1alpha1.ExecutionReasonPodDeleted, exec.Reason)
+		assert.Equal(t, "Testing pod was deleted before the execution finished", exec.Message)
+		assert.NotNil(t, exec.CompletionTime)
+		assert.Equal(t, v1alpha1.TestRunning, stat.Results[0].Status)
+		assert.Equal(t, conditionSuiteRunning(), stat.Conditions)
+		assert.Equal(t, []string{
+			"Warning ExecutionLost Execution [oct-tp-test-all-test-a-0] of test [name: test-a, namespace: default] failed: Testing pod was deleted before the execution finished",
+		}, recorder.events)
+	})
+
+	t.Run("waits for just scheduled pod", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(v1alpha1.TestExecution{ID: getPodNameForTestA(0), StartTime: &v1.Time{Time: getStartTime().Add(-time.Second)}})
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, nil)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, suite.Status.Results[0].Executions, stat.Results[0].Executions)
+	})
+
+	t.Run("fails execution with scheduled pod that never appeared", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(v1alpha1.TestExecution{ID: getPodNameForTestA(0), StartTime: &v1.Time{Time: getStartTime().Add(-2 * time.Minute)}})
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, nil)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.ExecutionReasonPodDeleted, stat.Results[0].Executions[0].Reason)
+	})
+
+	t.Run("fails execution with pod that is being deleted", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(v1alpha1.TestExecution{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning})
+		pod := getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodRunning})
+		pod.DeletionTimestamp = &v1.Time{Time: getStartTime()}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{pod})
+		// THEN
+		require.NoError(t, err)
+		exec := stat.Results[0].Executions[0]
+		assert.Equal(t, v12.PodFailed, exec.PodPhase)
+		assert.Equal(t, v1alpha1.ExecutionReasonPodDeleted, exec.Reason)
+		assert.Equal(t, "Testing pod is being deleted before the execution finished", exec.Message)
+	})
+
+	t.Run("fails execution with pod on lost node", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(v1alpha1.TestExecution{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning})
+		pod := getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodUnknown, Reason: "NodeLost"})
+		pod.Spec.NodeName = "node-1"
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{pod})
+		// THEN
+		require.NoError(t, err)
+		exec := stat.Results[0].Executions[0]
+		assert.Equal(t, v12.PodFailed, exec.PodPhase)
+		assert.Equal(t, v1alpha1.ExecutionReasonNodeLost, exec.Reason)
+		assert.Equal(t, "Node [node-1] of the testing pod was lost", exec.Message)
+	})
+}
+
+func TestEnsureStatusIsUpToDateWithFailureThreshold(t *testing.T) {
+	givenSuite := func(spec v1alpha1.TestSuiteSpec) v1alpha1.ClusterTestSuite {
+		return v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       spec,
+			Status: v1alpha1.TestSuiteStatus{
+				StartTime:  &v1.Time{Time: getStartTime()},
+				Conditions: conditionSuiteRunning(),
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning},
+					}},
+					{Name: "test-b", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestB(0), PodPhase: v12.PodRunning},
+					}},
+					{Name: "test-c", Namespace: "default", Status: v1alpha1.TestNotYetScheduled},
+				},
+			},
+		}
+	}
+	givenPods := []v12.Pod{
+		getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodFailed}),
+		getTestPodBInStatus(0, v12.PodStatus{Phase: v12.PodRunning}),
+	}
+
+	t.Run("skips not executed tests and waits for running ones", func(t *testing.T) {
+		// GIVEN
+		recorder := &fakeRecorder{}
+		sut := status.NewService(mockNowProvider(), recorder)
+		suite := givenSuite(v1alpha1.TestSuiteSpec{Count: 1, FailFast: true})
+		// WHEN
+		stat, pending, err := sut.EnsureStatusIsUpToDate(&suite, givenPods)
+		pending.Record()
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestFailed, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestRunning, stat.Results[1].Status)
+		assert.Equal(t, v12.PodRunning, stat.Results[1].Executions[0].PodPhase)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[2].Status)
+		assert.Equal(t, v1alpha1.TestReasonSuiteStopped, stat.Results[2].Reason)
+		assert.Equal(t, "Suite stopped because [1] test(s) failed", stat.Results[2].Message)
+		require.Len(t, stat.Conditions, 1)
+		assert.Equal(t, v1alpha1.SuiteRunning, stat.Conditions[0].Type)
+		assert.Equal(t, v1alpha1.ReasonFailureThresholdReached, stat.Conditions[0].Reason)
+		assert.Nil(t, stat.CompletionTime)
+		assert.Contains(t, recorder.events, "Warning FailureThresholdReached Suite stopped because [1] test(s) failed, no more testing pods will be created")
+	})
+
+	t.Run("aborts running tests", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(v1alpha1.TestSuiteSpec{Count: 1, FailFast: true, AbortRunningOnFailure: true})
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, givenPods)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestFailed, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestFailed, stat.Results[1].Status)
+		assert.Equal(t, v12.PodFailed, stat.Results[1].Executions[0].PodPhase)
+		assert.Equal(t, v1alpha1.ExecutionReasonAborted, stat.Results[1].Executions[0].Reason)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[2].Status)
+		assert.Equal(t, v1alpha1.SuiteFailed, stat.Conditions[1].Type)
+		assert.Equal(t, v1alpha1.StatusTrue, stat.Conditions[1].Status)
+		assert.Equal(t, v1alpha1.ReasonFailureThresholdReached, stat.Conditions[1].Reason)
+		assert.NotNil(t, stat.CompletionTime)
+	})
+
+	t.Run("finishes stopped suite once running tests finish", func(t *testing.T) {
+		// GIVEN
+		recorder := &fakeRecorder{}
+		sut := status.NewService(mockNowProvider(), recorder)
+		suite := givenSuite(v1alpha1.TestSuiteSpec{Count: 1, FailFast: true})
+		stopped, pending, err := sut.EnsureStatusIsUpToDate(&suite, givenPods)
+		pending.Record()
+		require.NoError(t, err)
+		suite.Status = *stopped
+		// WHEN
+		stat, pending, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{
+			getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodFailed}),
+			getTestPodBInStatus(0, v12.PodStatus{Phase: v12.PodSucceeded}),
+		})
+		pending.Record()
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestSucceeded, stat.Results[1].Status)
+		assert.Equal(t, v1alpha1.SuiteFailed, stat.Conditions[1].Type)
+		assert.Equal(t, v1alpha1.ReasonFailureThresholdReached, stat.Conditions[1].Reason)
+		assert.Equal(t, "Suite stopped because [1] test(s) failed", stat.Conditions[1].Message)
+		assert.NotNil(t, stat.CompletionTime)
+		thresholdEvents := 0
+		for _, e := range recorder.events {
+			if strings.Contains(e, "FailureThresholdReached") {
+				thresholdEvents++
+			}
+		}
+		assert.Equal(t, 1, thresholdEvents)
+	})
+
+	t.Run("does not stop before reaching max failures", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(v1alpha1.TestSuiteSpec{Count: 1, FailFast: true, MaxFailures: 2})
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, givenPods)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Results[2].Status)
+		assert.Empty(t, stat.Conditions[0].Reason)
+	})
+
+	t.Run("counts test with failed execution as failed if there are no retries", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(v1alpha1.TestSuiteSpec{Count: 3, FailFast: true})
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, givenPods)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestFailed, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[2].Status)
+		assert.Equal(t, v1alpha1.ReasonFailureThresholdReached, stat.Conditions[0].Reason)
+	})
+}
+
+func TestEnsureStatusIsUpToDateWithFlakyTests(t *testing.T) {
+	givenSuite := func(spec v1alpha1.TestSuiteSpec) v1alpha1.ClusterTestSuite {
+		return v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       spec,
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestA(0), PodPhase: v12.PodFailed},
+						{ID: getPodNameForTestA(1), PodPhase: v12.PodRunning},
+					}},
+					{Name: "test-b", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, DependsOn: []v1alpha1.TestDefReference{{Name: "test-a", Namespace: "default"}}},
+				},
+			},
+		}
+	}
+	givenPods := []v12.Pod{
+		getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodFailed}),
+		getTestPodAInStatus(1, v12.PodStatus{Phase: v12.PodSucceeded}),
+	}
+
+	t.Run("count: flaky test fails the suite", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(v1alpha1.TestSuiteSpec{Count: 2})
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, givenPods)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestFlaky, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[1].Status)
+		assert.Equal(t, v1alpha1.TestReasonDependencyNotSucceeded, stat.Results[1].Reason)
+		assert.Equal(t, v1alpha1.SuiteFailed, stat.Conditions[1].Type)
+		assert.Equal(t, v1alpha1.ReasonFlakyTests, stat.Conditions[1].Reason)
+		assert.Equal(t, "Flaky tests: [default/test-a]", stat.Conditions[1].Message)
+	})
+
+	t.Run("maxRetries: flaky test satisfies dependencies", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(specWithRetries(1))
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, givenPods)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestFlaky, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Results[1].Status)
+		assert.Equal(t, conditionSuiteRunning(), stat.Conditions)
+	})
+}
+
+func TestRerunFailedTests(t *testing.T) {
+	t.Run("resets failed tests and keeps previous executions", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				StartTime:      &v1.Time{Time: getTimeInPast()},
+				CompletionTime: &v1.Time{Time: getTimeInPast()},
+				Conditions: []v1alpha1.TestSuiteCondition{
+					{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusFalse},
+					{Type: v1alpha1.SuiteFailed, Status: v1alpha1.StatusTrue, Reason: v1alpha1.ReasonFailureThresholdReached},
+				},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestFailed, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestA(0), PodPhase: v12.PodFailed},
+					}},
+					{Name: "test-b", Namespace: "default", Status: v1alpha1.TestSucceeded, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestB(0), PodPhase: v12.PodSucceeded},
+					}},
+					{Name: "test-c", Namespace: "default", Status: v1alpha1.TestSkipped, Reason: v1alpha1.TestReasonSuiteStopped, Executions: []v1alpha1.TestExecution{}},
+					{Name: "test-d", Namespace: "default", Status: v1alpha1.TestSkipped, Executions: []v1alpha1.TestExecution{}},
+				},
+			},
+		}
+		// WHEN
+		stat, count := sut.RerunFailedTests(&suite)
+		// THEN
+		assert.Equal(t, 2, count)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Results[0].Status)
+		assert.Empty(t, stat.Results[0].Executions)
+		assert.Equal(t, []v1alpha1.TestExecution{{ID: getPodNameForTestA(0), PodPhase: v12.PodFailed}}, stat.Results[0].PreviousExecutions)
+		assert.Equal(t, v1alpha1.TestSucceeded, stat.Results[1].Status)
+		assert.Len(t, stat.Results[1].Executions, 1)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Results[2].Status)
+		assert.Empty(t, stat.Results[2].Reason)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[3].Status)
+
+		assert.Nil(t, stat.CompletionTime)
+		assert.Equal(t, getStartTime(), stat.StartTime.Time)
+		assert.Equal(t, v1alpha1.SuiteRunning, stat.Conditions[0].Type)
+		assert.Equal(t, v1alpha1.StatusTrue, stat.Conditions[0].Status)
+		assert.Equal(t, v1alpha1.StatusFalse, stat.Conditions[1].Status)
+		assert.Empty(t, stat.Conditions[1].Reason)
+		// original status is not modified
+		assert.Equal(t, v1alpha1.TestFailed, suite.Status.Results[0].Status)
+	})
+
+	t.Run("reruns tests skipped because the suite timed out", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteError, Status: v1alpha1.StatusTrue, Reason: v1alpha1.ReasonSuiteTimeout}},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSkipped, Reason: v1alpha1.TestReasonSuiteTimedOut, Message: "Suite exceeded timeout [1m0s]"},
+				},
+			},
+		}
+		// WHEN
+		stat, count := sut.RerunFailedTests(&suite)
+		// THEN
+		assert.Equal(t, 1, count)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Results[0].Status)
+		assert.Empty(t, stat.Results[0].Reason)
+		assert.Empty(t, stat.Results[0].Message)
+	})
+
+	t.Run("keeps skipping tests whose dependencies are not rerun", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteFailed, Status: v1alpha1.StatusTrue}},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSkipped},
+					{Name: "test-b", Namespace: "default", Status: v1alpha1.TestSkipped, Reason: v1alpha1.TestReasonDependencyNotSucceeded,
+						DependsOn: []v1alpha1.TestDefReference{{Name: "test-a", Namespace: "default"}}},
+					{Name: "test-c", Namespace: "default", Status: v1alpha1.TestFailed},
+				},
+			},
+		}
+		// WHEN
+		stat, count := sut.RerunFailedTests(&suite)
+		// THEN
+		assert.Equal(t, 2, count)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[1].Status)
+		assert.Equal(t, v1alpha1.TestReasonDependencyNotSucceeded, stat.Results[1].Reason)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Results[2].Status)
+	})
+
+	t.Run("does nothing if there are no failed tests", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteSucceeded, Status: v1alpha1.StatusTrue}},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSucceeded},
+				},
+			},
+		}
+		// WHEN
+		stat, count := sut.RerunFailedTests(&suite)
+		// THEN
+		assert.Equal(t, 0, count)
+		assert.Equal(t, suite.Status, *stat)
+	})
+}
+
+func TestSuiteHooks(t *testing.T) {
+	hookPod := func(name string, phase v12.PodPhase) v12.Pod {
+		pod := getTestPodAInStatus(0, v12.PodStatus{Phase: phase})
+		pod.Name = fmt.Sprintf("oct-tp-test-all-%s-0", name)
+		pod.Labels["testing.kyma-project.io/def-name"] = name
+		return pod
+	}
+	hookResult := func(name string, st v1alpha1.TestStatus, phase v12.PodPhase) *v1alpha1.TestResult {
+		return &v1alpha1.TestResult{Name: name, Namespace: "default", Status: st, Executions: []v1alpha1.TestExecution{
+			{ID: fmt.Sprintf("oct-tp-test-all-%s-0", name), PodPhase: phase},
+		}}
+	}
+
+	t.Run("initializes hooks and does not execute them as tests", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			Spec: v1alpha1.TestSuiteSpec{
+				Setup:    &v1alpha1.TestDefReference{Name: "seed", Namespace: "default"},
+				Teardown: &v1alpha1.TestDefReference{Name: "cleanup", Namespace: "default"},
+			},
+		}
+		seed := v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: "seed", Namespace: "default"},
+			Spec:       v1alpha1.TestDefinitionSpec{Timeout: &v1.Duration{Duration: time.Minute}},
+		}
+		cleanup := v1alpha1.TestDefinition{ObjectMeta: v1.ObjectMeta{Name: "cleanup", Namespace: "default"}}
+		// WHEN
+		stat, err := sut.InitializeTests(&suite, []v1alpha1.TestDefinition{
+			seed, {ObjectMeta: v1.ObjectMeta{Name: "test-a", Namespace: "default"}}, cleanup,
+		})
+		require.NoError(t, err)
+		sut.InitializeHooks(stat, &seed, &cleanup)
+		// THEN
+		require.Len(t, stat.Results, 1)
+		assert.Equal(t, "test-a", stat.Results[0].Name)
+		assert.Equal(t, &v1alpha1.TestResult{
+			Name:       "seed",
+			Namespace:  "default",
+			Status:     v1alpha1.TestNotYetScheduled,
+			Executions: []v1alpha1.TestExecution{},
+			Timeout:    &v1.Duration{Duration: time.Minute},
+		}, stat.Setup)
+		require.NotNil(t, stat.Teardown)
+		assert.Equal(t, "cleanup", stat.Teardown.Name)
+	})
+
+	t.Run("skips tests and fails the suite when setup failed", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Setup:      hookResult("seed", v1alpha1.TestRunning, v12.PodRunning),
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, Executions: []v1alpha1.TestExecution{}},
+				},
+			},
+		}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{hookPod("seed", v12.PodFailed)})
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestFailed, stat.Setup.Status)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestReasonSetupFailed, stat.Results[0].Reason)
+		assert.Equal(t, "Setup [name: seed, namespace: default] failed", stat.Results[0].Message)
+		assert.Contains(t, stat.Conditions, v1alpha1.TestSuiteCondition{
+			Type:    v1alpha1.SuiteFailed,
+			Status:  v1alpha1.StatusTrue,
+			Reason:  v1alpha1.ReasonSetupFailed,
+			Message: "Setup [name: seed, namespace: default] failed",
+		})
+	})
+
+	t.Run("suite with teardown in progress is not finished", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		conditions := []v1alpha1.TestSuiteCondition{
+			{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusFalse},
+			{Type: v1alpha1.SuiteSucceeded, Status: v1alpha1.StatusTrue},
+		}
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditions,
+				Teardown:   hookResult("cleanup", v1alpha1.TestScheduled, ""),
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSucceeded, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestA(0), PodPhase: v12.PodSucceeded},
+					}},
+				},
+			},
+		}
+		assert.False(t, sut.IsFinished(&suite))
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{hookPod("cleanup", v12.PodFailed)})
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestFailed, stat.Teardown.Status)
+		assert.Equal(t, conditions, stat.Conditions)
+		suite.Status = *stat
+		assert.True(t, sut.IsFinished(&suite))
+	})
+
+	t.Run("suite timeout interrupts setup", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1, SuiteTimeout: &v1.Duration{Duration: time.Minute}},
+			Status: v1alpha1.TestSuiteStatus{
+				StartTime:  &v1.Time{Time: getStartTime().Add(-2 * time.Minute)},
+				Conditions: conditionSuiteRunning(),
+				Setup:      hookResult("seed", v1alpha1.TestRunning, v12.PodRunning),
+				Teardown:   &v1alpha1.TestResult{Name: "cleanup", Namespace: "default", Status: v1alpha1.TestNotYetScheduled},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, Executions: []v1alpha1.TestExecution{}},
+				},
+			},
+		}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{hookPod("seed", v12.PodRunning)})
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestFailed, stat.Setup.Status)
+		assert.Equal(t, v1alpha1.ExecutionReasonSuiteTimedOut, stat.Setup.Executions[0].Reason)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[0].Status)
+		assert.Contains(t, stat.Conditions, v1alpha1.TestSuiteCondition{
+			Type:    v1alpha1.SuiteError,
+			Status:  v1alpha1.StatusTrue,
+			Reason:  v1alpha1.ReasonSuiteTimeout,
+			Message: "Suite exceeded timeout [1m0s], running tests were interrupted",
+		})
+		suite.Status = *stat
+		assert.False(t, sut.IsFinished(&suite))
+	})
+
+	t.Run("skips hooks when suite finished without executing any test", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Setup:      &v1alpha1.TestResult{Name: "seed", Namespace: "default", Status: v1alpha1.TestNotYetScheduled},
+				Teardown:   &v1alpha1.TestResult{Name: "cleanup", Namespace: "default", Status: v1alpha1.TestNotYetScheduled},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSkipped, Executions: []v1alpha1.TestExecution{}},
+				},
+			},
+		}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, nil)
+		// THEN
+		require.NoError(t, err)
+		for _, hook := range []*v1alpha1.TestResult{stat.Setup, stat.Teardown} {
+			assert.Equal(t, v1alpha1.TestSkipped, hook.Status)
+			assert.Equal(t, v1alpha1.TestReasonNoTestsExecuted, hook.Reason)
+			assert.Equal(t, "Suite finished without executing any test", hook.Message)
+		}
+		suite.Status = *stat
+		assert.True(t, sut.IsFinished(&suite))
+	})
+
+	t.Run("executes teardown when setup was executed and all tests were skipped", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditionSuiteRunning(),
+				Setup:      hookResult("seed", v1alpha1.TestSucceeded, v12.PodSucceeded),
+				Teardown:   &v1alpha1.TestResult{Name: "cleanup", Namespace: "default", Status: v1alpha1.TestNotYetScheduled},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSkipped, Executions: []v1alpha1.TestExecution{}},
+				},
+			},
+		}
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, nil)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestSucceeded, stat.Setup.Status)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Teardown.Status)
+		suite.Status = *stat
+		assert.False(t, sut.IsFinished(&suite))
+	})
+
+	t.Run("rerun executes hooks again", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteFailed, Status: v1alpha1.StatusTrue, Reason: v1alpha1.ReasonSetupFailed}},
+				Setup:      hookResult("seed", v1alpha1.TestFailed, v12.PodFailed),
+				Teardown:   hookResult("cleanup", v1alpha1.TestSucceeded, v12.PodSucceeded),
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSkipped, Reason: v1alpha1.TestReasonSetupFailed},
+				},
+			},
+		}
+		// WHEN
+		stat, count := sut.RerunFailedTests(&suite)
+		// THEN
+		assert.Equal(t, 1, count)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Results[0].Status)
+		for _, hook := range []*v1alpha1.TestResult{stat.Setup, stat.Teardown} {
+			assert.Equal(t, v1alpha1.TestNotYetScheduled, hook.Status)
+			assert.Empty(t, hook.Executions)
+			assert.Len(t, hook.PreviousExecutions, 1)
+		}
+	})
+}
+
+func TestEnsureStatusIsUpToDateWithPausedSuite(t *testing.T) {
+	givenSuite := func(spec v1alpha1.TestSuiteSpec, stat v1alpha1.TestSuiteStatus) v1alpha1.ClusterTestSuite {
+		stat.Conditions = conditionSuiteRunning()
+		stat.Results = []v1alpha1.TestResult{
+			{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+				{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning},
+			}},
+			{Name: "test-b", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, Executions: []v1alpha1.TestExecution{}},
+		}
+		spec.Count = 1
+		spec.SuiteTimeout = &v1.Duration{Duration: time.Hour}
+		return v1alpha1.ClusterTestSuite{ObjectMeta: v1.ObjectMeta{Name: "test-all"}, Spec: spec, Status: stat}
+	}
+
+	t.Run("records pause and finishes executions in progress", func(t *testing.T) {
+		// GIVEN
+		recorder := &fakeRecorder{}
+		sut := status.NewService(mockNowProvider(), recorder)
+		suite := givenSuite(v1alpha1.TestSuiteSpec{Paused: true}, v1alpha1.TestSuiteStatus{StartTime: &v1.Time{Time: getStartTime().Add(-time.Minute)}})
+		// WHEN
+		stat, pending, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodSucceeded})})
+		pending.Record()
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TestSucceeded, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Results[1].Status)
+		assert.Equal(t, conditionSuiteRunning(), stat.Conditions)
+		require.NotNil(t, stat.PausedAt)
+		assert.Nil(t, stat.PausedDuration)
+		assert.Contains(t, recorder.events, "Normal Paused Suite paused, no more testing pods will be created until it is resumed")
+	})
+
+	t.Run("adds pause to the total paused duration on resume", func(t *testing.T) {
+		// GIVEN
+		recorder := &fakeRecorder{}
+		sut := status.NewService(mockNowProvider(), recorder)
+		suite := givenSuite(v1alpha1.TestSuiteSpec{}, v1alpha1.TestSuiteStatus{
+			StartTime:      &v1.Time{Time: getStartTime().Add(-30 * time.Minute)},
+			PausedAt:       &v1.Time{Time: getStartTime().Add(-10 * time.Minute)},
+			PausedDuration: &v1.Duration{Duration: 5 * time.Minute},
+		})
+		// WHEN
+		stat, pending, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodRunning})})
+		pending.Record()
+		// THEN
+		require.NoError(t, err)
+		assert.Nil(t, stat.PausedAt)
+		assert.Equal(t, &v1.Duration{Duration: 15 * time.Minute}, stat.PausedDuration)
+		assert.Equal(t, []string{"Normal Resumed Suite resumed after [10m0s]"}, recorder.events)
+	})
+
+	t.Run("does not count pauses into suite timeout if requested", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := givenSuite(v1alpha1.TestSuiteSpec{Paused: true, PauseSuiteTimeout: true}, v1alpha1.TestSuiteStatus{
+			StartTime:      &v1.Time{Time: getStartTime().Add(-2 * time.Hour)},
+			PausedAt:       &v1.Time{Time: getStartTime().Add(-time.Hour)},
+			PausedDuration: &v1.Duration{Duration: 30 * time.Minute},
+		})
+		// WHEN
+		stat, _, err := sut.EnsureStatusIsUpToDate(&suite, []v12.Pod{getTestPodAInStatus(0, v12.PodStatus{Phase: v12.PodRunning})})
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, conditionSuiteRunning(), stat.Conditions)
+		assert.Nil(t, stat.CompletionTime)
+	})
+}
+
+func TestAbortSuite(t *testing.T) {
+	t.Run("interrupts running tests, skips not executed ones and keeps results", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+			Status: v1alpha1.TestSuiteStatus{
+				StartTime:  &v1.Time{Time: getStartTime()},
+				Conditions: conditionSuiteRunning(),
+				Teardown:   &v1alpha1.TestResult{Name: "cleanup", Namespace: "default", Status: v1alpha1.TestNotYetScheduled},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning},
+					}},
+					{Name: "test-b", Namespace: "default", Status: v1alpha1.TestSucceeded, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestB(0), PodPhase: v12.PodSucceeded},
+					}},
+					{Name: "test-c", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, Executions: []v1alpha1.TestExecution{}},
+				},
+			},
+		}
+		// WHEN
+		stat := sut.AbortSuite(&suite)
+		// THEN
+		assert.Equal(t, v1alpha1.TestFailed, stat.Results[0].Status)
+		assert.Equal(t, v12.PodFailed, stat.Results[0].Executions[0].PodPhase)
+		assert.Equal(t, v1alpha1.ExecutionReasonSuiteAborted, stat.Results[0].Executions[0].Reason)
+		assert.Equal(t, v1alpha1.TestSucceeded, stat.Results[1].Status)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[2].Status)
+		assert.Equal(t, v1alpha1.TestReasonSuiteAborted, stat.Results[2].Reason)
+		assert.Contains(t, stat.Conditions, v1alpha1.TestSuiteCondition{
+			Type:    v1alpha1.SuiteAborted,
+			Status:  v1alpha1.StatusTrue,
+			Message: "Suite aborted on user request",
+		})
+		assert.NotNil(t, stat.CompletionTime)
+		// original status is not modified
+		assert.Equal(t, v1alpha1.TestRunning, suite.Status.Results[0].Status)
+		// teardown still has to clean up
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Teardown.Status)
+		suite.Status = *stat
+		assert.False(t, sut.IsFinished(&suite))
+	})
+
+	t.Run("keeps succeeded setup and lets teardown run", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		setup := &v1alpha1.TestResult{Name: "seed", Namespace: "default", Status: v1alpha1.TestSucceeded, Executions: []v1alpha1.TestExecution{
+			{ID: "oct-tp-test-all-seed-0", PodPhase: v12.PodSucceeded},
+		}}
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+			Status: v1alpha1.TestSuiteStatus{
+				StartTime:  &v1.Time{Time: getStartTime()},
+				Conditions: conditionSuiteRunning(),
+				Setup:      setup,
+				Teardown:   &v1alpha1.TestResult{Name: "cleanup", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, Executions: []v1alpha1.TestExecution{}},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestA(0), PodPhase: v12.PodRunning},
+					}},
+				},
+			},
+		}
+		// WHEN
+		stat := sut.AbortSuite(&suite)
+		// THEN
+		assert.Equal(t, setup, stat.Setup)
+		assert.Equal(t, v1alpha1.TestFailed, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Teardown.Status)
+		assert.Empty(t, stat.Teardown.Reason)
+		assert.Contains(t, stat.Conditions, v1alpha1.TestSuiteCondition{
+			Type:    v1alpha1.SuiteAborted,
+			Status:  v1alpha1.StatusTrue,
+			Message: "Suite aborted on user request",
+		})
+		// WHEN teardown finishes
+		suite.Status = *stat
+		suite.Status.Teardown.Status = v1alpha1.TestSucceeded
+		suite.Status.Teardown.Executions = []v1alpha1.TestExecution{{ID: "oct-tp-test-all-cleanup-0", PodPhase: v12.PodSucceeded}}
+		// THEN
+		assert.True(t, sut.IsFinished(&suite))
+	})
+
+	t.Run("skips setup that was not executed yet", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Spec:       v1alpha1.TestSuiteSpec{Count: 1},
+			Status: v1alpha1.TestSuiteStatus{
+				StartTime:  &v1.Time{Time: getStartTime()},
+				Conditions: conditionSuiteRunning(),
+				Setup:      &v1alpha1.TestResult{Name: "seed", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, Executions: []v1alpha1.TestExecution{}},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestNotYetScheduled, Executions: []v1alpha1.TestExecution{}},
+				},
+			},
+		}
+		// WHEN
+		stat := sut.AbortSuite(&suite)
+		// THEN
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Setup.Status)
+		assert.Equal(t, v1alpha1.TestReasonSuiteAborted, stat.Setup.Reason)
+		assert.Equal(t, v1alpha1.TestSkipped, stat.Results[0].Status)
+		suite.Status = *stat
+		assert.True(t, sut.IsFinished(&suite))
+	})
+
+	t.Run("does not interrupt teardown of finished tests", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		conditions := []v1alpha1.TestSuiteCondition{
+			{Type: v1alpha1.SuiteRunning, Status: v1alpha1.StatusFalse},
+			{Type: v1alpha1.SuiteSucceeded, Status: v1alpha1.StatusTrue},
+		}
+		suite := v1alpha1.ClusterTestSuite{
+			ObjectMeta: v1.ObjectMeta{Name: "test-all"},
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: conditions,
+				Teardown: &v1alpha1.TestResult{Name: "cleanup", Namespace: "default", Status: v1alpha1.TestRunning, Executions: []v1alpha1.TestExecution{
+					{ID: "oct-tp-test-all-cleanup-0", PodPhase: v12.PodRunning},
+				}},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSucceeded, Executions: []v1alpha1.TestExecution{
+						{ID: getPodNameForTestA(0), PodPhase: v12.PodSucceeded},
+					}},
+				},
+			},
+		}
+		// WHEN
+		stat := sut.AbortSuite(&suite)
+		// THEN
+		assert.Equal(t, suite.Status, *stat)
+		assert.False(t, sut.IsFinished(&suite))
+	})
+
+	t.Run("rerun resumes tests skipped because of abort", func(t *testing.T) {
+		// GIVEN
+		sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				Conditions: []v1alpha1.TestSuiteCondition{{Type: v1alpha1.SuiteAborted, Status: v1alpha1.StatusTrue}},
+				Setup:      &v1alpha1.TestResult{Name: "seed", Namespace: "default", Status: v1alpha1.TestSkipped, Reason: v1alpha1.TestReasonSuiteAborted},
+				Results: []v1alpha1.TestResult{
+					{Name: "test-a", Namespace: "default", Status: v1alpha1.TestSkipped, Reason: v1alpha1.TestReasonSuiteAborted},
+				},
+			},
+		}
+		// WHEN
+		stat, count := sut.RerunFailedTests(&suite)
+		// THEN
+		assert.Equal(t, 1, count)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Results[0].Status)
+		assert.Equal(t, v1alpha1.TestNotYetScheduled, stat.Setup.Status)
+		assert.Empty(t, stat.Setup.Reason)
+	})
+}
+
+func specWithRetries(retries int64) v1alpha1.TestSuiteSpec {
+	return v1alpha1.TestSuiteSpec{
+		MaxRetries: retries,
+	}
+}
+
+func conditionSuiteRunning() []v1alpha1.TestSuiteCondition {
+	return []v1alpha1.TestSuiteCondition{
+		{
+			Type:   v1alpha1.SuiteRunning,
+			Status: v1alpha1.StatusTrue,
+		},
+	}
+}
+
+func TestMarkAsScheduled(t *testing.T) {
+	// GIVEN
+	sut := status.NewService(mockNowProvider(), &fakeRecorder{})
+	// WHEN
+	actStatus, err := sut.MarkAsScheduled(v1alpha1.TestSuiteStatus{
+		Conditions: []v1alpha1.TestSuiteCondition{
+			{
+				Type: v1alpha1.SuiteRunning,
+			},
+		},
+		StartTime: &v1.Time{Time: getTimeInPast()},
+		Results: []v1alpha1.TestResult{
+			{
+				Name:      "test-a",
+				Namespace: "default",
+				Status:    v1alpha1.TestNotYetScheduled,
+			},
+		},
+	}, "test-a", "default", "", getPodNameForTestA(0))
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1.TestSuiteStatus{
+		Conditions: []v1alpha1.TestSuiteCondition{
+			{
+				Type: v1alpha1.SuiteRunning,
+			},
+		},
+		StartTime: &v1.Time{Time: getTimeInPast()},
+		Results: []v1alpha1.TestResult{
+			{
+				Name:      "test-a",
+				Namespace: "default",
+				Status:    v1alpha1.TestScheduled,
+				Executions: []v1alpha1.TestExecution{
+					{
+						ID:        getPodNameForTestA(0),
+						StartTime: &v1.Time{Time: getStartTime()},
+					},
+				},
+			},
+		},
+	}, actStatus)
+}
+
+func TestGetExecutionsInProgress(t *testing.T) {
+	sut := status.NewService(nil, &fakeRecorder{})
+	t.Run("returns nil if no tests to run", func(t *testing.T) {
+		// GIVEN
+		suite := v1alpha1.ClusterTestSuite{}
+		// WHEN
+		actual := sut.GetExecutionsInProgress(&suite)
+		// THEN
+		require.Empty(t, actual)
+	})
+	t.Run("returns only Pending and Running executions", func(t *testing.T) {
+		// GIVEN
+		suite := v1alpha1.ClusterTestSuite{
+			Status: v1alpha1.TestSuiteStatus{
+				Results: []v1alpha1.TestResult{
+					{
+						Name: "test-1",
+						Executions: []v1alpha1.TestExecution{
+							{
+								ID:       "id-111",
+								PodPhase: v12.PodSucceeded,
+							},
+							{
+								ID:       "id-112",
+								PodPhase: v12.PodRunning,
+							},
+							{
+								ID:       "id-113",
+								PodPhase: v12.PodFailed,
+							},
+						},
+					},
+					{
+						Name: "test-2",
+						Executions: []v1alpha1.TestExecution{
+							{
+								ID:       "id-211",
+								PodPhase: v12.PodPending,
+							},
+							{
+								ID:       "id-212",
+								PodPhase: v12.PodFailed,
+							},
+						},
+					},
+				},
+			},
+		}
+		// WHEN
+		actual := sut.GetExecutionsInProgress(&suite)
+		// THEN
 		require.Len(t, actual, 2)
 		assert.Contains(t, actual, v1alpha1.TestExecution{ID: "id-112", PodPhase: v12.PodRunning})
 		assert.Contains(t, actual, v1alpha1.TestExecution{ID: "id-211", PodPhase: v12.PodPending})
@@ -1072,3 +2655,17 @@ func getTestPodBInStatus(exec int, podStatus v12.PodStatus) v12.Pod {
 		Status: podStatus,
 	}
 }
+
+type fakeRecorder struct {
+	events      []string
+	failedTests []string
+}
+
+func (r *fakeRecorder) SuiteEvent(suite v1alpha1.GenericTestSuite, eventType, reason, messageFmt string, args ...interface{}) {
+	r.events = append(r.events, fmt.Sprintf("%s %s %s", eventType, reason, fmt.Sprintf(messageFmt, args...)))
+}
+
+func (r *fakeRecorder) TestFailureEvent(suite v1alpha1.GenericTestSuite, res v1alpha1.TestResult, reason, messageFmt string, args ...interface{}) {
+	r.SuiteEvent(suite, v12.EventTypeWarning, reason, messageFmt, args...)
+	r.failedTests = append(r.failedTests, fmt.Sprintf("%s/%s", res.Namespace, res.Name))
+}
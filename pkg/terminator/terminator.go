@@ -0,0 +1,61 @@
+package terminator
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Service deletes testing pods that are still running, but their executions are already finished from
+// the suite point of view, e.g. because they exceeded a timeout.
+type Service struct {
+	writer client.Writer
+	log    logr.Logger
+}
+
+func NewService(writer client.Writer, logger logr.Logger) *Service {
+	return &Service{
+		writer: writer,
+		log:    logger,
+	}
+}
+
+func (s *Service) TerminateInterrupted(ctx context.Context, suite v1alpha1.GenericTestSuite, pods []v1.Pod) error {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		exec, found := s.findExecution(*suite.GetStatus(), pod)
+		if !found || !s.isInterrupted(exec) {
+			continue
+		}
+		s.log.Info("Deleting testing pod of interrupted execution", "suite", suite.GetName(), "podName", pod.Name, "podNs", pod.Namespace, "reason", exec.Reason)
+		if err := s.writer.Delete(ctx, pod.DeepCopy()); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "while deleting testing pod [name: %s, namespace: %s] for suite [%s]", pod.Name, pod.Namespace, suite.GetName())
+		}
+	}
+	return nil
+}
+
+func (s *Service) findExecution(stat v1alpha1.TestSuiteStatus, pod v1.Pod) (v1alpha1.TestExecution, bool) {
+	for _, tr := range stat.GetAllResults() {
+		if tr.Name != pod.Labels[v1alpha1.LabelKeyTestDefName] || tr.Namespace != pod.Namespace {
+			continue
+		}
+		for _, exec := range tr.Executions {
+			if exec.ID == pod.Name {
+				return exec, true
+			}
+		}
+	}
+	return v1alpha1.TestExecution{}, false
+}
+
+func (s *Service) isInterrupted(exec v1alpha1.TestExecution) bool {
+	return exec.PodPhase == v1.PodSucceeded || exec.PodPhase == v1.PodFailed
+}
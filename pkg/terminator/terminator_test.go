@@ -0,0 +1,113 @@
+package terminator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/terminator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	rlog "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestTerminateInterrupted(t *testing.T) {
+	// GIVEN
+	timedOutPod := givenPod("oct-tp-test-all-test-a-0", "test-a", v12.PodRunning)
+	runningPod := givenPod("oct-tp-test-all-test-b-0", "test-b", v12.PodRunning)
+	finishedPod := givenPod("oct-tp-test-all-test-c-0", "test-c", v12.PodSucceeded)
+
+	suite := v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-all",
+		},
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				{
+					Name:      "test-a",
+					Namespace: "default",
+					Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-a-0", PodPhase: v12.PodFailed, Reason: v1alpha1.ExecutionReasonTimedOut},
+					},
+				},
+				{
+					Name:      "test-b",
+					Namespace: "default",
+					Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-b-0", PodPhase: v12.PodRunning},
+					},
+				},
+				{
+					Name:      "test-c",
+					Namespace: "default",
+					Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-c-0", PodPhase: v12.PodSucceeded},
+					},
+				},
+			},
+		},
+	}
+
+	fakeCli := fake.NewFakeClient(&timedOutPod, &runningPod, &finishedPod)
+	sut := terminator.NewService(fakeCli, rlog.Log)
+
+	// WHEN
+	err := sut.TerminateInterrupted(context.TODO(), &suite, []v12.Pod{timedOutPod, runningPod, finishedPod})
+
+	// THEN
+	require.NoError(t, err)
+	err = fakeCli.Get(context.TODO(), types.NamespacedName{Name: timedOutPod.Name, Namespace: timedOutPod.Namespace}, &v12.Pod{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.NoError(t, fakeCli.Get(context.TODO(), types.NamespacedName{Name: runningPod.Name, Namespace: runningPod.Namespace}, &v12.Pod{}))
+	assert.NoError(t, fakeCli.Get(context.TODO(), types.NamespacedName{Name: finishedPod.Name, Namespace: finishedPod.Namespace}, &v12.Pod{}))
+}
+
+func TestTerminateInterruptedIgnoresAlreadyDeletedPod(t *testing.T) {
+	// GIVEN
+	timedOutPod := givenPod("oct-tp-test-all-test-a-0", "test-a", v12.PodRunning)
+	suite := v1alpha1.ClusterTestSuite{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-all",
+		},
+		Status: v1alpha1.TestSuiteStatus{
+			Results: []v1alpha1.TestResult{
+				{
+					Name:      "test-a",
+					Namespace: "default",
+					Executions: []v1alpha1.TestExecution{
+						{ID: "oct-tp-test-all-test-a-0", PodPhase: v12.PodFailed, Reason: v1alpha1.ExecutionReasonTimedOut},
+					},
+				},
+			},
+		},
+	}
+	sut := terminator.NewService(fake.NewFakeClient(), rlog.Log)
+
+	// WHEN
+	err := sut.TerminateInterrupted(context.TODO(), &suite, []v12.Pod{timedOutPod})
+
+	// THEN
+	require.NoError(t, err)
+}
+
+func givenPod(name, testName string, phase v12.PodPhase) v12.Pod {
+	return v12.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				v1alpha1.LabelKeyCreatedByOctopus: "true",
+				v1alpha1.LabelKeySuiteName:        "test-all",
+				v1alpha1.LabelKeyTestDefName:      testName,
+			},
+		},
+		Status: v12.PodStatus{
+			Phase: phase,
+		},
+	}
+}
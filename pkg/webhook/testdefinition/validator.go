@@ -0,0 +1,97 @@
+package testdefinition
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/naming"
+	"github.com/kyma-incubator/octopus/pkg/scheduler"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// shortestSuiteName is used to check if a test definition name leaves any room for suite names in testing pod names
+const shortestSuiteName = "x"
+
+// Validator rejects TestDefinitions with invalid spec
+type Validator struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &Validator{}
+var _ admission.DecoderInjector = &Validator{}
+
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	def := &v1alpha1.TestDefinition{}
+	if err := v.decoder.Decode(req, def); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if errs := ValidateDefinition(*def); len(errs) > 0 {
+		return admission.Denied(errs.ToAggregate().Error())
+	}
+	return admission.Allowed("")
+}
+
+func (v *Validator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+func ValidateDefinition(def v1alpha1.TestDefinition) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if def.Spec.Timeout != nil && def.Spec.Timeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(specPath.Child("timeout"), def.Spec.Timeout.Duration.String(), "must be greater than 0"))
+	}
+	for idx, dep := range def.Spec.DependsOn {
+		depPath := specPath.Child("dependsOn").Index(idx)
+		if dep.Name == "" {
+			errs = append(errs, field.Required(depPath.Child("name"), ""))
+		}
+		if dep.Namespace == "" {
+			errs = append(errs, field.Required(depPath.Child("namespace"), ""))
+		}
+		if dep.Name == def.Name && dep.Namespace == def.Namespace {
+			errs = append(errs, field.Invalid(depPath, dep.Name, "test definition cannot depend on itself"))
+		}
+	}
+	if err := scheduler.ValidateNameLength(shortestSuiteName, def.Name, 1); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), def.Name, err.Error()))
+	}
+	errs = append(errs, validateMatrix(def, specPath.Child("matrix"))...)
+
+	return errs
+}
+
+// validateMatrix checks that variant names can be used in names and labels of testing pods,
+// and that parameters can be used as environment variables
+func validateMatrix(def v1alpha1.TestDefinition, matrixPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	names := make(map[string]struct{}, len(def.Spec.Matrix))
+	for idx, variant := range def.Spec.Matrix {
+		variantPath := matrixPath.Index(idx)
+		namePath := variantPath.Child("name")
+		if variant.Name == "" {
+			errs = append(errs, field.Required(namePath, ""))
+		} else if msgs := validation.IsDNS1123Label(variant.Name); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(namePath, variant.Name, strings.Join(msgs, ", ")))
+		} else if _, found := names[variant.Name]; found {
+			errs = append(errs, field.Duplicate(namePath, variant.Name))
+		} else if err := scheduler.ValidateNameLength(shortestSuiteName, naming.TestName(def.Name, variant.Name), 1); err != nil {
+			errs = append(errs, field.Invalid(namePath, variant.Name, err.Error()))
+		}
+		names[variant.Name] = struct{}{}
+
+		for param := range variant.Parameters {
+			if msgs := validation.IsEnvVarName(param); len(msgs) > 0 {
+				errs = append(errs, field.Invalid(variantPath.Child("parameters").Key(param), param, strings.Join(msgs, ", ")))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,98 @@
+package testdefinition_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/webhook/testdefinition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateDefinition(t *testing.T) {
+	t.Run("accepts valid definition", func(t *testing.T) {
+		// GIVEN
+		def := v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: "test-a", Namespace: "default"},
+			Spec: v1alpha1.TestDefinitionSpec{
+				Timeout: &v1.Duration{Duration: time.Minute},
+			},
+		}
+		// WHEN & THEN
+		assert.Empty(t, testdefinition.ValidateDefinition(def))
+	})
+
+	t.Run("rejects not positive timeout", func(t *testing.T) {
+		// GIVEN
+		def := v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: "test-a", Namespace: "default"},
+			Spec: v1alpha1.TestDefinitionSpec{
+				Timeout: &v1.Duration{Duration: -time.Minute},
+			},
+		}
+		// WHEN
+		errs := testdefinition.ValidateDefinition(def)
+		// THEN
+		require.Len(t, errs, 1)
+		assert.Equal(t, "spec.timeout", errs[0].Field)
+	})
+
+	t.Run("rejects invalid dependencies", func(t *testing.T) {
+		// GIVEN
+		def := v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: "test-a", Namespace: "default"},
+			Spec: v1alpha1.TestDefinitionSpec{
+				DependsOn: []v1alpha1.TestDefReference{
+					{Name: "test-b", Namespace: "default"},
+					{Name: "test-c"},
+					{Name: "test-a", Namespace: "default"},
+				},
+			},
+		}
+		// WHEN
+		errs := testdefinition.ValidateDefinition(def)
+		// THEN
+		require.Len(t, errs, 2)
+		assert.Equal(t, "spec.dependsOn[1].namespace", errs[0].Field)
+		assert.Equal(t, "spec.dependsOn[2]", errs[1].Field)
+	})
+
+	t.Run("rejects invalid matrix", func(t *testing.T) {
+		// GIVEN
+		def := v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: "test-a", Namespace: "default"},
+			Spec: v1alpha1.TestDefinitionSpec{
+				Matrix: []v1alpha1.TestVariant{
+					{Name: "postgres", Parameters: map[string]string{"DB_HOST": "postgres"}},
+					{Name: "postgres"},
+					{Name: "MySQL"},
+					{},
+					{Name: "mongo", Parameters: map[string]string{"1DB": "mongo"}},
+				},
+			},
+		}
+		// WHEN
+		errs := testdefinition.ValidateDefinition(def)
+		// THEN
+		require.Len(t, errs, 4)
+		assert.Equal(t, "spec.matrix[1].name", errs[0].Field)
+		assert.Equal(t, "spec.matrix[2].name", errs[1].Field)
+		assert.Equal(t, "spec.matrix[3].name", errs[2].Field)
+		assert.Equal(t, "spec.matrix[4].parameters[1DB]", errs[3].Field)
+	})
+
+	t.Run("rejects name that generates too long testing pod names", func(t *testing.T) {
+		// GIVEN
+		def := v1alpha1.TestDefinition{
+			ObjectMeta: v1.ObjectMeta{Name: strings.Repeat("a", 250), Namespace: "default"},
+		}
+		// WHEN
+		errs := testdefinition.ValidateDefinition(def)
+		// THEN
+		require.Len(t, errs, 1)
+		assert.Equal(t, "metadata.name", errs[0].Field)
+	})
+}
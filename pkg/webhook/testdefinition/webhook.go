@@ -0,0 +1,18 @@
+package testdefinition
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	ValidatingPath = "/validate-testing-kyma-project-io-v1alpha1-testdefinition"
+)
+
+// Add registers validating webhook for TestDefinition in the webhook server of the Manager.
+//
+// +kubebuilder:webhook:path=/validate-testing-kyma-project-io-v1alpha1-testdefinition,mutating=false,failurePolicy=fail,groups=testing.kyma-project.io,resources=testdefinitions,verbs=create;update,versions=v1alpha1,name=vtestdefinition.testing.kyma-project.io
+func Add(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(ValidatingPath, &webhook.Admission{Handler: &Validator{}})
+	return nil
+}
@@ -0,0 +1,37 @@
+package testsuite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Defaulter persists default values of ClusterTestSuite and TestSuite spec
+type Defaulter struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &Defaulter{}
+var _ admission.DecoderInjector = &Defaulter{}
+
+func (d *Defaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	suite, err := decodeSuite(d.decoder, req)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	suite.GetSpec().SetDefaults()
+
+	marshaled, err := json.Marshal(suite)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+func (d *Defaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.decoder = decoder
+	return nil
+}
@@ -0,0 +1,228 @@
+package testsuite
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/scheduler"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// shortestDefName is used to check if a suite name leaves any room for test definition names in testing pod names
+const shortestDefName = "x"
+
+// Validator rejects ClusterTestSuites and TestSuites with invalid spec
+type Validator struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &Validator{}
+var _ admission.DecoderInjector = &Validator{}
+
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	suite, err := decodeSuite(v.decoder, req)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if errs := ValidateSuite(suite); len(errs) > 0 {
+		return admission.Denied(errs.ToAggregate().Error())
+	}
+	return admission.Allowed("")
+}
+
+func (v *Validator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+func ValidateSuite(suite v1alpha1.GenericTestSuite) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	spec := *suite.GetSpec()
+
+	if spec.Concurrency < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("concurrency"), spec.Concurrency, "must be greater than or equal to 0"))
+	}
+	if spec.Count < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("count"), spec.Count, "must be greater than or equal to 0"))
+	}
+	if spec.MaxRetries < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("maxRetries"), spec.MaxRetries, "must be greater than or equal to 0"))
+	}
+	if spec.MaxFailures < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("maxFailures"), spec.MaxFailures, "must be greater than or equal to 0"))
+	}
+	if spec.Count > 1 && spec.MaxRetries > 0 {
+		errs = append(errs, field.Forbidden(specPath.Child("maxRetries"), "cannot be used together with count greater than 1"))
+	}
+	if spec.SuiteTimeout != nil && spec.SuiteTimeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(specPath.Child("suiteTimeout"), spec.SuiteTimeout.Duration.String(), "must be greater than 0"))
+	}
+	if spec.PendingTimeout != nil && spec.PendingTimeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(specPath.Child("pendingTimeout"), spec.PendingTimeout.Duration.String(), "must be greater than 0"))
+	}
+	errs = append(errs, validateResourceBudget(specPath.Child("resourceBudget"), spec.ResourceBudget)...)
+	switch spec.PodRetentionPolicy {
+	case "", v1alpha1.PodRetentionKeepAll, v1alpha1.PodRetentionKeepFailed, v1alpha1.PodRetentionKeepNone:
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("podRetentionPolicy"), spec.PodRetentionPolicy,
+			[]string{string(v1alpha1.PodRetentionKeepAll), string(v1alpha1.PodRetentionKeepFailed), string(v1alpha1.PodRetentionKeepNone)}))
+	}
+	if spec.PodTTLSecondsAfterFinished != nil && *spec.PodTTLSecondsAfterFinished < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("podTTLSecondsAfterFinished"), *spec.PodTTLSecondsAfterFinished, "must be greater than or equal to 0"))
+	}
+	if spec.TTLSecondsAfterFinished != nil && *spec.TTLSecondsAfterFinished < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("ttlSecondsAfterFinished"), *spec.TTLSecondsAfterFinished, "must be greater than or equal to 0"))
+	}
+
+	exprPath := specPath.Child("selectors", "matchLabelExpressions")
+	for idx, expr := range spec.Selectors.MatchLabelExpressions {
+		if _, err := labels.Parse(expr); err != nil {
+			errs = append(errs, field.Invalid(exprPath.Index(idx), expr, err.Error()))
+		}
+	}
+
+	executions := maxExecutions(spec)
+	if err := scheduler.ValidateNameLength(suite.GetName(), shortestDefName, executions); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), suite.GetName(), err.Error()))
+	}
+	namesPath := specPath.Child("selectors", "matchNames")
+	for idx, ref := range spec.Selectors.MatchNames {
+		if err := scheduler.ValidateNameLength(suite.GetName(), ref.Name, executions); err != nil {
+			errs = append(errs, field.Invalid(namesPath.Index(idx).Child("name"), ref.Name, err.Error()))
+		}
+		if v1alpha1.IsNamespaced(suite) && ref.Namespace != suite.GetNamespace() {
+			errs = append(errs, field.Invalid(namesPath.Index(idx).Child("namespace"), ref.Namespace, "must be equal to the namespace of the TestSuite"))
+		}
+	}
+
+	errs = append(errs, validateHooks(suite, specPath)...)
+	errs = append(errs, validateInjectedSpec(spec, specPath)...)
+
+	return errs
+}
+
+// validateHooks checks references to the setup and teardown. They cannot refer to the same TestDefinition,
+// because each of them has its own testing pods.
+func validateHooks(suite v1alpha1.GenericTestSuite, specPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	spec := suite.GetSpec()
+	hooks := []struct {
+		path *field.Path
+		ref  *v1alpha1.TestDefReference
+	}{
+		{path: specPath.Child("setup"), ref: spec.Setup},
+		{path: specPath.Child("teardown"), ref: spec.Teardown},
+	}
+	for _, hook := range hooks {
+		if hook.ref == nil {
+			continue
+		}
+		if hook.ref.Name == "" {
+			errs = append(errs, field.Required(hook.path.Child("name"), ""))
+		} else if err := scheduler.ValidateNameLength(suite.GetName(), hook.ref.Name, 1); err != nil {
+			errs = append(errs, field.Invalid(hook.path.Child("name"), hook.ref.Name, err.Error()))
+		}
+		if hook.ref.Namespace == "" {
+			errs = append(errs, field.Required(hook.path.Child("namespace"), ""))
+		} else if v1alpha1.IsNamespaced(suite) && hook.ref.Namespace != suite.GetNamespace() {
+			errs = append(errs, field.Invalid(hook.path.Child("namespace"), hook.ref.Namespace, "must be equal to the namespace of the TestSuite"))
+		}
+	}
+	if spec.Setup != nil && spec.Teardown != nil && *spec.Setup == *spec.Teardown {
+		errs = append(errs, field.Invalid(specPath.Child("teardown"), spec.Teardown.Name, "must be different from the setup"))
+	}
+	return errs
+}
+
+// validateInjectedSpec checks environment and volumes that are merged into every testing pod, so that invalid values
+// are rejected before any testing pod is created
+func validateInjectedSpec(spec v1alpha1.TestSuiteSpec, specPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for idx, env := range spec.Env {
+		namePath := specPath.Child("env").Index(idx).Child("name")
+		if env.Name == "" {
+			errs = append(errs, field.Required(namePath, ""))
+		} else if msgs := validation.IsEnvVarName(env.Name); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(namePath, env.Name, strings.Join(msgs, ", ")))
+		}
+	}
+	for idx, src := range spec.EnvFrom {
+		srcPath := specPath.Child("envFrom").Index(idx)
+		switch {
+		case src.ConfigMapRef != nil && src.SecretRef != nil:
+			errs = append(errs, field.Forbidden(srcPath, "may not have more than one source specified"))
+		case src.ConfigMapRef != nil && src.ConfigMapRef.Name == "":
+			errs = append(errs, field.Required(srcPath.Child("configMapRef", "name"), ""))
+		case src.SecretRef != nil && src.SecretRef.Name == "":
+			errs = append(errs, field.Required(srcPath.Child("secretRef", "name"), ""))
+		case src.ConfigMapRef == nil && src.SecretRef == nil:
+			errs = append(errs, field.Required(srcPath, "must specify configMapRef or secretRef"))
+		}
+	}
+
+	volumes := make(map[string]struct{}, len(spec.Volumes))
+	for idx, vol := range spec.Volumes {
+		namePath := specPath.Child("volumes").Index(idx).Child("name")
+		if vol.Name == "" {
+			errs = append(errs, field.Required(namePath, ""))
+		} else if msgs := validation.IsDNS1123Label(vol.Name); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(namePath, vol.Name, strings.Join(msgs, ", ")))
+		} else if _, found := volumes[vol.Name]; found {
+			errs = append(errs, field.Duplicate(namePath, vol.Name))
+		}
+		volumes[vol.Name] = struct{}{}
+	}
+	mountPaths := make(map[string]struct{}, len(spec.VolumeMounts))
+	for idx, mount := range spec.VolumeMounts {
+		mountPath := specPath.Child("volumeMounts").Index(idx)
+		// a mount can also refer to a volume from the TestDefinition, which is checked when the suite is initialized
+		if mount.Name == "" {
+			errs = append(errs, field.Required(mountPath.Child("name"), ""))
+		}
+		if mount.MountPath == "" {
+			errs = append(errs, field.Required(mountPath.Child("mountPath"), ""))
+		} else if _, found := mountPaths[mount.MountPath]; found {
+			errs = append(errs, field.Duplicate(mountPath.Child("mountPath"), mount.MountPath))
+		}
+		mountPaths[mount.MountPath] = struct{}{}
+	}
+	return errs
+}
+
+// validateResourceBudget allows only cpu and memory, because the budget is compared with requests of testing pods
+func validateResourceBudget(path *field.Path, budget v1.ResourceList) field.ErrorList {
+	var errs field.ErrorList
+	names := make([]string, 0, len(budget))
+	for name := range budget {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		q := budget[v1.ResourceName(name)]
+		switch v1.ResourceName(name) {
+		case v1.ResourceCPU, v1.ResourceMemory:
+			if q.Sign() <= 0 {
+				errs = append(errs, field.Invalid(path.Key(name), q.String(), "must be greater than 0"))
+			}
+		default:
+			errs = append(errs, field.NotSupported(path.Key(name), name, []string{string(v1.ResourceCPU), string(v1.ResourceMemory)}))
+		}
+	}
+	return errs
+}
+
+func maxExecutions(spec v1alpha1.TestSuiteSpec) int64 {
+	if spec.MaxRetries > 0 {
+		return spec.MaxRetries + 1
+	}
+	return spec.Count
+}
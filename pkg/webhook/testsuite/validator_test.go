@@ -0,0 +1,334 @@
+package testsuite_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-incubator/octopus/pkg/webhook/testsuite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestValidateSuite(t *testing.T) {
+	t.Run("accepts valid suite", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			Concurrency:  2,
+			Count:        1,
+			MaxRetries:   3,
+			SuiteTimeout: &v1.Duration{Duration: time.Hour},
+			Selectors: v1alpha1.TestsSelector{
+				MatchNames:            []v1alpha1.TestDefReference{{Name: "test-a", Namespace: "default"}},
+				MatchLabelExpressions: []string{"env in (dev,stage)"},
+			},
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		assert.Empty(t, errs)
+	})
+
+	t.Run("rejects negative values", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			Concurrency:    -1,
+			Count:          -1,
+			MaxRetries:     -1,
+			MaxFailures:    -1,
+			SuiteTimeout:   &v1.Duration{Duration: -time.Hour},
+			PendingTimeout: &v1.Duration{},
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 6)
+		assert.Equal(t, "spec.concurrency", errs[0].Field)
+		assert.Equal(t, "spec.count", errs[1].Field)
+		assert.Equal(t, "spec.maxRetries", errs[2].Field)
+		assert.Equal(t, "spec.maxFailures", errs[3].Field)
+		assert.Equal(t, "spec.suiteTimeout", errs[4].Field)
+		assert.Equal(t, "spec.pendingTimeout", errs[5].Field)
+	})
+
+	t.Run("rejects count used together with maxRetries", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			Count:      2,
+			MaxRetries: 1,
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "spec.maxRetries: Forbidden: cannot be used together with count greater than 1")
+	})
+
+	t.Run("rejects invalid resource budget", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			ResourceBudget: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("0"),
+				corev1.ResourceMemory:           resource.MustParse("1Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+			},
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 2)
+		assert.EqualError(t, errs[0], "spec.resourceBudget[cpu]: Invalid value: \"0\": must be greater than 0")
+		assert.Equal(t, "spec.resourceBudget[ephemeral-storage]", errs[1].Field)
+	})
+
+	t.Run("accepts environment and volumes", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			Env:          []corev1.EnvVar{{Name: "CLUSTER_URL", Value: "https://cluster.local"}},
+			EnvFrom:      []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}}}},
+			Volumes:      []corev1.Volume{{Name: "certs", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "certs"}}}},
+			VolumeMounts: []corev1.VolumeMount{{Name: "certs", MountPath: "/etc/certs"}},
+		})
+		// WHEN & THEN
+		assert.Empty(t, testsuite.ValidateSuite(suite))
+	})
+
+	t.Run("accepts mounts of volumes from test definitions", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			VolumeMounts: []corev1.VolumeMount{{Name: "fixtures", MountPath: "/fixtures"}},
+		})
+		// WHEN & THEN
+		assert.Empty(t, testsuite.ValidateSuite(suite))
+	})
+
+	t.Run("rejects invalid environment and volumes", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			Env: []corev1.EnvVar{{Name: "1_BUILD_ID"}, {Value: "value"}},
+			EnvFrom: []corev1.EnvFromSource{
+				{ConfigMapRef: &corev1.ConfigMapEnvSource{}},
+				{},
+			},
+			Volumes: []corev1.Volume{{Name: "certs"}, {Name: "certs"}},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "certs", MountPath: "/etc/certs"},
+				{MountPath: "/etc/certs"},
+			},
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 7)
+		assert.Equal(t, "spec.env[0].name", errs[0].Field)
+		assert.EqualError(t, errs[1], "spec.env[1].name: Required value")
+		assert.EqualError(t, errs[2], "spec.envFrom[0].configMapRef.name: Required value")
+		assert.Equal(t, "spec.envFrom[1]", errs[3].Field)
+		assert.EqualError(t, errs[4], "spec.volumes[1].name: Duplicate value: \"certs\"")
+		assert.EqualError(t, errs[5], "spec.volumeMounts[1].name: Required value")
+		assert.EqualError(t, errs[6], "spec.volumeMounts[1].mountPath: Duplicate value: \"/etc/certs\"")
+	})
+
+	t.Run("rejects invalid retention", func(t *testing.T) {
+		// GIVEN
+		negative := int64(-1)
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			PodRetentionPolicy:         "KeepSucceeded",
+			PodTTLSecondsAfterFinished: &negative,
+			TTLSecondsAfterFinished:    &negative,
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 3)
+		assert.Equal(t, "spec.podRetentionPolicy", errs[0].Field)
+		assert.EqualError(t, errs[1], "spec.podTTLSecondsAfterFinished: Invalid value: -1: must be greater than or equal to 0")
+		assert.EqualError(t, errs[2], "spec.ttlSecondsAfterFinished: Invalid value: -1: must be greater than or equal to 0")
+	})
+
+	t.Run("rejects invalid setup and teardown", func(t *testing.T) {
+		// GIVEN
+		suite := givenNamespacedSuite("test-all", "team-a", v1alpha1.TestSuiteSpec{
+			Setup:    &v1alpha1.TestDefReference{Name: "seed"},
+			Teardown: &v1alpha1.TestDefReference{Name: "seed", Namespace: "team-b"},
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 2)
+		assert.EqualError(t, errs[0], "spec.setup.namespace: Required value")
+		assert.EqualError(t, errs[1], "spec.teardown.namespace: Invalid value: \"team-b\": must be equal to the namespace of the TestSuite")
+	})
+
+	t.Run("rejects setup used as teardown", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			Setup:    &v1alpha1.TestDefReference{Name: "seed", Namespace: "default"},
+			Teardown: &v1alpha1.TestDefReference{Name: "seed", Namespace: "default"},
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 1)
+		assert.Equal(t, "spec.teardown", errs[0].Field)
+	})
+
+	t.Run("rejects unparsable label expressions", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			Selectors: v1alpha1.TestsSelector{
+				MatchLabelExpressions: []string{"valid=true", "in in in"},
+			},
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 1)
+		assert.Equal(t, "spec.selectors.matchLabelExpressions[1]", errs[0].Field)
+	})
+
+	t.Run("rejects names that generate too long testing pod names", func(t *testing.T) {
+		// GIVEN
+		suite := givenSuite("test-all", v1alpha1.TestSuiteSpec{
+			Count: 1,
+			Selectors: v1alpha1.TestsSelector{
+				MatchNames: []v1alpha1.TestDefReference{{Name: strings.Repeat("a", 240), Namespace: "default"}},
+			},
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 1)
+		assert.Equal(t, "spec.selectors.matchNames[0].name", errs[0].Field)
+
+		// GIVEN
+		suite = givenSuite(strings.Repeat("a", 250), v1alpha1.TestSuiteSpec{Count: 1})
+		// WHEN
+		errs = testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 1)
+		assert.Equal(t, "metadata.name", errs[0].Field)
+	})
+
+	t.Run("rejects namespaced suite selecting tests from other namespace", func(t *testing.T) {
+		// GIVEN
+		suite := givenNamespacedSuite("test-all", "team-a", v1alpha1.TestSuiteSpec{
+			Selectors: v1alpha1.TestsSelector{
+				MatchNames: []v1alpha1.TestDefReference{
+					{Name: "test-a", Namespace: "team-a"},
+					{Name: "test-b", Namespace: "team-b"},
+				},
+			},
+		})
+		// WHEN
+		errs := testsuite.ValidateSuite(suite)
+		// THEN
+		require.Len(t, errs, 1)
+		assert.Equal(t, "spec.selectors.matchNames[1].namespace", errs[0].Field)
+	})
+}
+
+func TestValidatorHandle(t *testing.T) {
+	sut := &testsuite.Validator{}
+	require.NoError(t, sut.InjectDecoder(givenDecoder(t)))
+
+	t.Run("allows valid suite", func(t *testing.T) {
+		// WHEN
+		resp := sut.Handle(context.TODO(), givenRequest(t, givenSuite("test-all", v1alpha1.TestSuiteSpec{Count: 1})))
+		// THEN
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("allows valid namespaced suite", func(t *testing.T) {
+		// WHEN
+		resp := sut.Handle(context.TODO(), givenRequest(t, givenNamespacedSuite("test-all", "default", v1alpha1.TestSuiteSpec{Count: 1})))
+		// THEN
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("denies invalid suite with a message", func(t *testing.T) {
+		// WHEN
+		resp := sut.Handle(context.TODO(), givenRequest(t, givenSuite("test-all", v1alpha1.TestSuiteSpec{Count: 2, MaxRetries: 1})))
+		// THEN
+		assert.False(t, resp.Allowed)
+		require.NotNil(t, resp.Result)
+		assert.Contains(t, string(resp.Result.Reason), "spec.maxRetries: Forbidden: cannot be used together with count greater than 1")
+	})
+}
+
+func TestDefaulterHandle(t *testing.T) {
+	// GIVEN
+	sut := &testsuite.Defaulter{}
+	require.NoError(t, sut.InjectDecoder(givenDecoder(t)))
+
+	// WHEN
+	resp := sut.Handle(context.TODO(), givenRequest(t, givenSuite("test-all", v1alpha1.TestSuiteSpec{Concurrency: 3})))
+
+	// THEN
+	assert.True(t, resp.Allowed)
+	paths := make(map[string]interface{})
+	for _, p := range resp.Patches {
+		paths[p.Path] = p.Value
+	}
+	assert.Len(t, paths, 4)
+	assert.Equal(t, float64(1), paths["/spec/count"])
+	assert.Equal(t, "1h0m0s", paths["/spec/suiteTimeout"])
+	assert.Equal(t, "5m0s", paths["/spec/pendingTimeout"])
+	assert.Equal(t, "KeepAll", paths["/spec/podRetentionPolicy"])
+}
+
+func givenSuite(name string, spec v1alpha1.TestSuiteSpec) *v1alpha1.ClusterTestSuite {
+	return &v1alpha1.ClusterTestSuite{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "ClusterTestSuite",
+			APIVersion: "testing.kyma-project.io/v1alpha1",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: spec,
+	}
+}
+
+func givenDecoder(t *testing.T) *admission.Decoder {
+	sch, err := v1alpha1.SchemeBuilder.Build()
+	require.NoError(t, err)
+	decoder, err := admission.NewDecoder(sch)
+	require.NoError(t, err)
+	return decoder
+}
+
+func givenNamespacedSuite(name, ns string, spec v1alpha1.TestSuiteSpec) *v1alpha1.TestSuite {
+	return &v1alpha1.TestSuite{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "TestSuite",
+			APIVersion: "testing.kyma-project.io/v1alpha1",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: spec,
+	}
+}
+
+func givenRequest(t *testing.T, suite v1alpha1.GenericTestSuite) admission.Request {
+	raw, err := json.Marshal(suite)
+	require.NoError(t, err)
+	return admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Kind:      v1.GroupVersionKind(suite.GetObjectKind().GroupVersionKind()),
+			Operation: admissionv1beta1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package testsuite
+
+import (
+	"github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	MutatingPath             = "/mutate-testing-kyma-project-io-v1alpha1-clustertestsuite"
+	ValidatingPath           = "/validate-testing-kyma-project-io-v1alpha1-clustertestsuite"
+	NamespacedMutatingPath   = "/mutate-testing-kyma-project-io-v1alpha1-testsuite"
+	NamespacedValidatingPath = "/validate-testing-kyma-project-io-v1alpha1-testsuite"
+)
+
+// Add registers defaulting and validating webhooks for ClusterTestSuite and TestSuite in the webhook server of the Manager.
+//
+// +kubebuilder:webhook:path=/mutate-testing-kyma-project-io-v1alpha1-clustertestsuite,mutating=true,failurePolicy=fail,groups=testing.kyma-project.io,resources=clustertestsuites,verbs=create;update,versions=v1alpha1,name=mclustertestsuite.testing.kyma-project.io
+// +kubebuilder:webhook:path=/validate-testing-kyma-project-io-v1alpha1-clustertestsuite,mutating=false,failurePolicy=fail,groups=testing.kyma-project.io,resources=clustertestsuites,verbs=create;update,versions=v1alpha1,name=vclustertestsuite.testing.kyma-project.io
+// +kubebuilder:webhook:path=/mutate-testing-kyma-project-io-v1alpha1-testsuite,mutating=true,failurePolicy=fail,groups=testing.kyma-project.io,resources=testsuites,verbs=create;update,versions=v1alpha1,name=mtestsuite.testing.kyma-project.io
+// +kubebuilder:webhook:path=/validate-testing-kyma-project-io-v1alpha1-testsuite,mutating=false,failurePolicy=fail,groups=testing.kyma-project.io,resources=testsuites,verbs=create;update,versions=v1alpha1,name=vtestsuite.testing.kyma-project.io
+func Add(mgr manager.Manager) error {
+	srv := mgr.GetWebhookServer()
+	srv.Register(MutatingPath, &webhook.Admission{Handler: &Defaulter{}})
+	srv.Register(ValidatingPath, &webhook.Admission{Handler: &Validator{}})
+	srv.Register(NamespacedMutatingPath, &webhook.Admission{Handler: &Defaulter{}})
+	srv.Register(NamespacedValidatingPath, &webhook.Admission{Handler: &Validator{}})
+	return nil
+}
+
+// decodeSuite decodes a ClusterTestSuite or a TestSuite, depending on the kind of the admission request
+func decodeSuite(decoder *admission.Decoder, req admission.Request) (v1alpha1.GenericTestSuite, error) {
+	var suite v1alpha1.GenericTestSuite = &v1alpha1.ClusterTestSuite{}
+	if req.Kind.Kind == "TestSuite" {
+		suite = &v1alpha1.TestSuite{}
+	}
+	if err := decoder.Decode(req, suite); err != nil {
+		return nil, err
+	}
+	return suite, nil
+}